@@ -0,0 +1,23 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RecommendationSpec sets the defaults of a RecommendationSpec.
+func SetDefaults_RecommendationSpec(obj *RecommendationSpec) {
+	if obj.AdoptionType == "" {
+		obj.AdoptionType = AdoptionTypeStatusAndAnnotation
+	}
+}
+
+// SetDefaults_CompletionStrategy sets the defaults of a CompletionStrategy.
+func SetDefaults_CompletionStrategy(obj *CompletionStrategy) {
+	if obj.CompletionStrategyType == "" {
+		obj.CompletionStrategyType = CompletionStrategyOnce
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by defaulter-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&Analytics{}, func(obj interface{}) { SetObjectDefaults_Analytics(obj.(*Analytics)) })
+	scheme.AddTypeDefaultingFunc(&AnalyticsList{}, func(obj interface{}) { SetObjectDefaults_AnalyticsList(obj.(*AnalyticsList)) })
+	scheme.AddTypeDefaultingFunc(&Recommendation{}, func(obj interface{}) { SetObjectDefaults_Recommendation(obj.(*Recommendation)) })
+	scheme.AddTypeDefaultingFunc(&RecommendationList{}, func(obj interface{}) { SetObjectDefaults_RecommendationList(obj.(*RecommendationList)) })
+	return nil
+}
+
+func SetObjectDefaults_Analytics(in *Analytics) {
+	SetDefaults_CompletionStrategy(&in.Spec.CompletionStrategy)
+}
+
+func SetObjectDefaults_AnalyticsList(in *AnalyticsList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_Analytics(a)
+	}
+}
+
+func SetObjectDefaults_Recommendation(in *Recommendation) {
+	SetDefaults_RecommendationSpec(&in.Spec)
+	SetDefaults_CompletionStrategy(&in.Spec.CompletionStrategy)
+}
+
+func SetObjectDefaults_RecommendationList(in *RecommendationList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_Recommendation(a)
+	}
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	vpatypes "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_EffectiveHorizontalPodAutoscalerSpec sets the defaults of an EffectiveHorizontalPodAutoscalerSpec.
+func SetDefaults_EffectiveHorizontalPodAutoscalerSpec(obj *EffectiveHorizontalPodAutoscalerSpec) {
+	if obj.MinReplicas == nil {
+		minReplicas := int32(1)
+		obj.MinReplicas = &minReplicas
+	}
+	if obj.ScaleStrategy == "" {
+		obj.ScaleStrategy = ScaleStrategyAuto
+	}
+}
+
+// SetDefaults_Prediction sets the defaults of a Prediction.
+func SetDefaults_Prediction(obj *Prediction) {
+	if obj.PredictionWindowSeconds == nil {
+		predictionWindowSeconds := int32(3600)
+		obj.PredictionWindowSeconds = &predictionWindowSeconds
+	}
+}
+
+// SetDefaults_ContainerScalingPolicy sets the defaults of a ContainerScalingPolicy.
+func SetDefaults_ContainerScalingPolicy(obj *ContainerScalingPolicy) {
+	if obj.ScaleMode == nil {
+		scaleMode := vpatypes.ContainerScalingModeAuto
+		obj.ScaleMode = &scaleMode
+	}
+	if obj.StabilizationWindowSeconds == nil {
+		stabilizationWindowSeconds := int32(3600)
+		obj.StabilizationWindowSeconds = &stabilizationWindowSeconds
+	}
+}
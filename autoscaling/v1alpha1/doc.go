@@ -1,4 +1,5 @@
 // Package v1alpha1 is the v1alpha1 version of the autoscaling API.
 // +k8s:deepcopy-gen=package,register
+// +k8s:defaulter-gen=TypeMeta
 // +groupName=autoscaling.crane.io
 package v1alpha1
@@ -118,6 +118,10 @@ type PredictionAlgorithm struct {
 	DSP *predictionapi.DSP `json:"dsp,omitempty"`
 	// +optional
 	Percentile *predictionapi.Percentile `json:"percentile,omitempty"`
+	// +optional
+	Linear *predictionapi.Linear `json:"linear,omitempty"`
+	// +optional
+	HoltWinters *predictionapi.HoltWinters `json:"holtWinters,omitempty"`
 }
 
 type ConditionType string
@@ -0,0 +1,19 @@
+package v1alpha1
+
+const (
+	// LabelEffectiveHPAUID is the label key of the selector of the external metrics
+	// that an EffectiveHorizontalPodAutoscaler adds to its HorizontalPodAutoscaler.
+	// Its value is the uid of the EffectiveHorizontalPodAutoscaler.
+	LabelEffectiveHPAUID = "autoscaling.crane.io/effective-hpa-uid"
+
+	// LabelEffectiveHPAName is the label key of the objects generated for an
+	// EffectiveHorizontalPodAutoscaler. Its value is the name of the EffectiveHorizontalPodAutoscaler.
+	LabelEffectiveHPAName = "autoscaling.crane.io/effective-hpa-name"
+)
+
+const (
+	// AnnotationMetricQueryPrefix prefixes the annotation keys of an EffectiveHorizontalPodAutoscaler
+	// that override the query used to predict one of its metrics. The key is the prefix followed by
+	// the metric name, e.g. "metric-query.autoscaling.crane.io/http_requests", and the value is an expression query such as promQL.
+	AnnotationMetricQueryPrefix = "metric-query.autoscaling.crane.io/"
+)
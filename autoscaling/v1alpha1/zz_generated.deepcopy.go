@@ -469,6 +469,16 @@ func (in *PredictionAlgorithm) DeepCopyInto(out *PredictionAlgorithm) {
 	if in.Percentile != nil {
 		in, out := &in.Percentile, &out.Percentile
 		*out = new(predictionv1alpha1.Percentile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Linear != nil {
+		in, out := &in.Linear, &out.Linear
+		*out = new(predictionv1alpha1.Linear)
+		**out = **in
+	}
+	if in.HoltWinters != nil {
+		in, out := &in.HoltWinters, &out.HoltWinters
+		*out = new(predictionv1alpha1.HoltWinters)
 		**out = **in
 	}
 	return
@@ -0,0 +1,73 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by defaulter-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	predictionv1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&EffectiveHorizontalPodAutoscaler{}, func(obj interface{}) {
+		SetObjectDefaults_EffectiveHorizontalPodAutoscaler(obj.(*EffectiveHorizontalPodAutoscaler))
+	})
+	scheme.AddTypeDefaultingFunc(&EffectiveHorizontalPodAutoscalerList{}, func(obj interface{}) {
+		SetObjectDefaults_EffectiveHorizontalPodAutoscalerList(obj.(*EffectiveHorizontalPodAutoscalerList))
+	})
+	scheme.AddTypeDefaultingFunc(&EffectiveVerticalPodAutoscaler{}, func(obj interface{}) {
+		SetObjectDefaults_EffectiveVerticalPodAutoscaler(obj.(*EffectiveVerticalPodAutoscaler))
+	})
+	scheme.AddTypeDefaultingFunc(&EffectiveVerticalPodAutoscalerList{}, func(obj interface{}) {
+		SetObjectDefaults_EffectiveVerticalPodAutoscalerList(obj.(*EffectiveVerticalPodAutoscalerList))
+	})
+	return nil
+}
+
+func SetObjectDefaults_EffectiveHorizontalPodAutoscaler(in *EffectiveHorizontalPodAutoscaler) {
+	SetDefaults_EffectiveHorizontalPodAutoscalerSpec(&in.Spec)
+	if in.Spec.Prediction != nil {
+		SetDefaults_Prediction(in.Spec.Prediction)
+		if in.Spec.Prediction.PredictionAlgorithm != nil {
+			if in.Spec.Prediction.PredictionAlgorithm.Linear != nil {
+				predictionv1alpha1.SetDefaults_Linear(in.Spec.Prediction.PredictionAlgorithm.Linear)
+			}
+			if in.Spec.Prediction.PredictionAlgorithm.HoltWinters != nil {
+				predictionv1alpha1.SetDefaults_HoltWinters(in.Spec.Prediction.PredictionAlgorithm.HoltWinters)
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_EffectiveHorizontalPodAutoscalerList(in *EffectiveHorizontalPodAutoscalerList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_EffectiveHorizontalPodAutoscaler(a)
+	}
+}
+
+func SetObjectDefaults_EffectiveVerticalPodAutoscaler(in *EffectiveVerticalPodAutoscaler) {
+	if in.Spec.ResourcePolicy != nil {
+		for i := range in.Spec.ResourcePolicy.ContainerPolicies {
+			a := &in.Spec.ResourcePolicy.ContainerPolicies[i]
+			if a.ScaleUpPolicy != nil {
+				SetDefaults_ContainerScalingPolicy(a.ScaleUpPolicy)
+			}
+			if a.ScaleDownPolicy != nil {
+				SetDefaults_ContainerScalingPolicy(a.ScaleDownPolicy)
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_EffectiveVerticalPodAutoscalerList(in *EffectiveVerticalPodAutoscalerList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_EffectiveVerticalPodAutoscaler(a)
+	}
+}
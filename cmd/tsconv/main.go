@@ -0,0 +1,119 @@
+// Command tsconv converts time series between the CSV and JSON formats of package format, and
+// exports the status of a TimeSeriesPrediction.
+//
+//	tsconv -from tsp -to status-csv -i prediction.yaml -o prediction.csv
+//	tsconv -from json -to csv < recorded.json > recorded.csv
+//
+// The formats are csv and json for a MetricTimeSeriesList, status-csv and status-json for the
+// status of a TimeSeriesPrediction, and tsp, input only, for a TimeSeriesPrediction object in YAML
+// or JSON. A list converted to a status is the prediction of a metric without ResourceIdentifier,
+// and a status converted to a list is the predicted series of all its metrics.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/gocrane/api/pkg/prediction/format"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func main() {
+	from := flag.String("from", "csv", "input format: csv, json, status-csv, status-json or tsp")
+	to := flag.String("to", "json", "output format: csv, json, status-csv or status-json")
+	input := flag.String("i", "", "input file, standard input by default")
+	output := flag.String("o", "", "output file, standard output by default")
+	flag.Parse()
+
+	if err := run(*from, *to, *input, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "tsconv:", err)
+		os.Exit(1)
+	}
+}
+
+func run(from, to, input, output string) (err error) {
+	r := io.Reader(os.Stdin)
+	if input != "" {
+		f, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	status, err := read(r, from)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = f
+	}
+	return write(w, to, status)
+}
+
+func read(r io.Reader, from string) (*predictionapi.TimeSeriesPredictionStatus, error) {
+	var list predictionapi.MetricTimeSeriesList
+	var err error
+	switch from {
+	case "csv":
+		list, err = format.ReadCSV(r)
+	case "json":
+		list, err = format.ReadJSON(r)
+	case "status-csv":
+		return format.ReadStatusCSV(r)
+	case "status-json":
+		return format.ReadStatusJSON(r)
+	case "tsp":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		var tsp predictionapi.TimeSeriesPrediction
+		if err := yaml.Unmarshal(data, &tsp); err != nil {
+			return nil, err
+		}
+		return &tsp.Status, nil
+	default:
+		return nil, fmt.Errorf("unknown input format %q", from)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &predictionapi.TimeSeriesPredictionStatus{
+		PredictionMetrics: []predictionapi.PredictionMetricStatus{{Prediction: list}},
+	}, nil
+}
+
+func write(w io.Writer, to string, status *predictionapi.TimeSeriesPredictionStatus) error {
+	var list predictionapi.MetricTimeSeriesList
+	for _, metric := range status.PredictionMetrics {
+		list = append(list, metric.Prediction...)
+	}
+	switch to {
+	case "csv":
+		return format.WriteCSV(w, list)
+	case "json":
+		return format.WriteJSON(w, list)
+	case "status-csv":
+		return format.WriteStatusCSV(w, status)
+	case "status-json":
+		return format.WriteStatusJSON(w, status)
+	default:
+		return fmt.Errorf("unknown output format %q", to)
+	}
+}
@@ -0,0 +1,46 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_Rule sets the defaults of a Rule.
+func SetDefaults_Rule(obj *Rule) {
+	if obj.AvoidanceThreshold == 0 {
+		obj.AvoidanceThreshold = 1
+	}
+	if obj.RestoreThreshold == 0 {
+		obj.RestoreThreshold = 1
+	}
+	if obj.Strategy == "" {
+		obj.Strategy = AvoidanceActionStrategyNone
+	}
+}
+
+// SetDefaults_NodeLocalGet sets the defaults of a NodeLocalGet.
+func SetDefaults_NodeLocalGet(obj *NodeLocalGet) {
+	if obj.LocalCacheTTLSeconds == 0 {
+		obj.LocalCacheTTLSeconds = 60
+	}
+}
+
+// SetDefaults_AvoidanceActionSpec sets the defaults of an AvoidanceActionSpec.
+func SetDefaults_AvoidanceActionSpec(obj *AvoidanceActionSpec) {
+	if obj.CoolDownSeconds == 0 {
+		obj.CoolDownSeconds = 300
+	}
+}
+
+// SetDefaults_MemoryCompression sets the defaults of a MemoryCompression.
+func SetDefaults_MemoryCompression(obj *MemoryCompression) {
+	if obj.Preference == "" {
+		obj.Preference = PreferenceTiny
+	}
+	if obj.Oversold == "" {
+		obj.Oversold = OversoldAllow
+	}
+}
@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSchemeDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add to scheme: %v", err)
+	}
+
+	nodeQOS := &NodeQOS{
+		Spec: NodeQOSSpec{
+			NodeQualityProbe: NodeQualityProbe{NodeLocalGet: &NodeLocalGet{}},
+			Rules:            []Rule{{Name: "cpu-usage", AvoidanceThreshold: 2}},
+		},
+	}
+	scheme.Default(nodeQOS)
+
+	if got := nodeQOS.Spec.NodeQualityProbe.NodeLocalGet.LocalCacheTTLSeconds; got != 60 {
+		t.Errorf("LocalCacheTTLSeconds = %d, want 60", got)
+	}
+	rule := nodeQOS.Spec.Rules[0]
+	if rule.AvoidanceThreshold != 2 || rule.RestoreThreshold != 1 || rule.Strategy != AvoidanceActionStrategyNone {
+		t.Errorf("rule defaults = %+v, want avoidanceThreshold 2, restoreThreshold 1 and strategy None", rule)
+	}
+
+	action := &AvoidanceAction{}
+	scheme.Default(action)
+	if action.Spec.CoolDownSeconds != 300 {
+		t.Errorf("CoolDownSeconds = %d, want 300", action.Spec.CoolDownSeconds)
+	}
+}
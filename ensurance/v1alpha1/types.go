@@ -18,6 +18,12 @@ const (
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:resource:scope=Cluster,shortName=pq,path=podqoss
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Precedence",type="integer",JSONPath=".spec.precedence",description="The precedence of the PodQOS."
+// +kubebuilder:printcolumn:name="MatchedPods",type="integer",JSONPath=".status.matchedPods",description="The number of pods matched by the PodQOS."
+// +kubebuilder:printcolumn:name="Applied",type="string",JSONPath=".status.conditions[?(@.type==\"Applied\")].status",description="Whether the PodQOS is applied to all matched pods."
+// +kubebuilder:printcolumn:name="Conflict",type="string",JSONPath=".status.conditions[?(@.type==\"Conflict\")].status",description="Whether fields of the PodQOS are overridden by other PodQOS."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created."
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type PodQOS struct {
 	metav1.TypeMeta `json:",inline"`
@@ -37,6 +43,15 @@ type PodQOSSpec struct {
 	// by the scoped-resource selector requirements.
 	ScopeSelector *ScopeSelector `json:"scopeSelector,omitempty"`
 
+	// Precedence orders the PodQOS that match the same pod. The effective ResourceQOS of a pod is merged
+	// field by field: every field is taken from the PodQOS with the highest precedence that sets it, and
+	// between equal precedences from the PodQOS whose name sorts first. A field left at its zero value,
+	// such as a disabled htIsolation, is not set and does not override a lower precedence; only fields
+	// that are pointers, such as cpuPriority, can set a zero value.
+	// The PodQOS each field was taken from are recorded in the AnnotationPodQOSSources annotation of the pod.
+	// +optional
+	Precedence int32 `json:"precedence,omitempty"`
+
 	// ResourceQOS describe the QOS limit for cpu,memory,netIO,diskIO and so on.
 	ResourceQOS ResourceQOS `json:"resourceQOS,omitempty"`
 
@@ -54,6 +69,7 @@ const (
 	// QOSClassSelectors used to select workload with designated QOSClass, example: ["BestEffort","Guaranteed"]
 	QOSClassSelector ScopeName = "QOSClass"
 	// PrioritySelectors used to select workload with designated priority, contains an operator and values.
+	// A value is an integer, example: "1000", or an inclusive range, example: "1000..2000", "..0", where a missing bound is unbounded.
 	PrioritySelectors ScopeName = "Priority"
 	// NamespaceSelectors used to select workload by namespace
 	NamespaceSelectors ScopeName = "Namespace"
@@ -247,7 +263,64 @@ type DiskIOLimit struct {
 	WriteBPS  int64 `json:"writeBps,omitempty"`
 }
 
+// PodQOSStatus is the observed state of a PodQOS.
 type PodQOSStatus struct {
+	// ObservedGeneration is the generation of the PodQOS the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MatchedPods is the number of pods matched by the PodQOS.
+	// +optional
+	MatchedPods int32 `json:"matchedPods,omitempty"`
+	// MatchedPodSamples is a bounded sample of the pods matched by the PodQOS, sorted by namespace and name.
+	// +optional
+	MatchedPodSamples []PodReference `json:"matchedPodSamples,omitempty"`
+	// Nodes is the result of the application of the PodQOS on each node running matched pods, sorted by node name.
+	// +optional
+	Nodes []PodQOSNodeStatus `json:"nodes,omitempty"`
+	// Conditions is the condition of PodQOS
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PodQOSConditionType is a valid value for the type of a condition of PodQOS.
+type PodQOSConditionType string
+
+// These are valid conditions of PodQOS.
+const (
+	// PodQOSConditionApplied means the PodQOS is applied to all the pods it matches.
+	PodQOSConditionApplied PodQOSConditionType = "Applied"
+	// PodQOSConditionPartiallyApplied means the PodQOS is applied to some but not all of the pods it matches.
+	PodQOSConditionPartiallyApplied PodQOSConditionType = "PartiallyApplied"
+	// PodQOSConditionConflict means fields set by the PodQOS are overridden for some of the pods it matches
+	// by other PodQOS of higher precedence.
+	PodQOSConditionConflict PodQOSConditionType = "Conflict"
+)
+
+// PodReference references a pod.
+type PodReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// PodQOSNodeStatus is the result of the application of a PodQOS on a node.
+type PodQOSNodeStatus struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+	// MatchedPods is the number of pods on the node matched by the PodQOS.
+	MatchedPods int32 `json:"matchedPods"`
+	// AppliedPods is the number of matched pods on the node the effective ResourceQOS is applied to.
+	// +optional
+	AppliedPods int32 `json:"appliedPods,omitempty"`
+	// ConflictPods is the number of matched pods on the node for which fields set by the PodQOS are
+	// overridden by other PodQOS.
+	// +optional
+	ConflictPods int32 `json:"conflictPods,omitempty"`
+	// Message is a human readable message indicating details about the application, such as why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastUpdateTime is the last time the node reported the result.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 type PodQualityProbe struct {
@@ -268,6 +341,12 @@ type PodQOSEnsurancePolicyStatus struct {
 // +genclient
 // +genclient:nonNamespaced
 // +kubebuilder:resource:scope=Cluster,shortName=nq,path=nodeqoss
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.nodeNumber",description="The number of nodes reporting the state of the rules."
+// +kubebuilder:printcolumn:name="TriggeredNodes",type="integer",JSONPath=".status.triggeredNodes",description="The number of nodes with triggered rules."
+// +kubebuilder:printcolumn:name="TriggeredRules",type="integer",JSONPath=".status.triggeredRules",description="The number of rules triggered across the nodes."
+// +kubebuilder:printcolumn:name="Triggered",type="string",JSONPath=".status.conditions[?(@.type==\"Triggered\")].status",description="Whether actions are taken on a node."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created."
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NodeQOS is the Schema for the nodeqos API
@@ -426,6 +505,117 @@ type MetricRule struct {
 
 // NodeQOSStatus defines the observed status of NodeQOS
 type NodeQOSStatus struct {
+	// ObservedGeneration is the generation of the NodeQOS the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// NodeNumber is the number of nodes reporting the state of the rules.
+	// +optional
+	NodeNumber int32 `json:"nodeNumber,omitempty"`
+	// TriggeredNodes is the number of nodes with at least one triggered rule, previewed ones included.
+	// +optional
+	TriggeredNodes int32 `json:"triggeredNodes,omitempty"`
+	// TriggeredRules is the number of rules triggered across the nodes, previewed ones included.
+	// +optional
+	TriggeredRules int32 `json:"triggeredRules,omitempty"`
+	// Nodes is a bounded sample of the state of the rules on the nodes with at least one triggered rule,
+	// sorted by node name. It holds at most 10 of the TriggeredNodes.
+	// +optional
+	Nodes []NodeQOSNodeStatus `json:"nodes,omitempty"`
+	// History is a bounded list of the recent transitions of the rules across the nodes, most recent first.
+	// +optional
+	History []RuleTransition `json:"history,omitempty"`
+	// Conditions is the condition of NodeQOS
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// NodeQOSConditionType is a valid value for the type of a condition of NodeQOS.
+type NodeQOSConditionType string
+
+// These are valid conditions of NodeQOS.
+const (
+	// NodeQOSConditionTriggered means a rule with the None strategy is triggered on at least one node,
+	// so its action is taken.
+	NodeQOSConditionTriggered NodeQOSConditionType = "Triggered"
+	// NodeQOSConditionPreviewed means a rule with the Preview strategy is triggered on at least one node,
+	// so its action would be taken.
+	NodeQOSConditionPreviewed NodeQOSConditionType = "Previewed"
+)
+
+// RuleState is the state of a rule on a node.
+type RuleState string
+
+const (
+	// RuleStateNormal means the rule is not triggered.
+	RuleStateNormal RuleState = "Normal"
+	// RuleStateTriggered means the rule is triggered: its metric reached the value AvoidanceThreshold
+	// consecutive times and has not restored since.
+	RuleStateTriggered RuleState = "Triggered"
+)
+
+// NodeQOSNodeStatus is the state of the rules of a NodeQOS on a node.
+type NodeQOSNodeStatus struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+	// Rules is the state of each rule on the node, in the order of the rules.
+	// +optional
+	Rules []RuleStatus `json:"rules,omitempty"`
+	// LastUpdateTime is the last time the node reported the state.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// RuleStatus is the state of a rule on a node.
+type RuleStatus struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+	// State is the state of the rule.
+	State RuleState `json:"state"`
+	// Value is the last observed value of the metric of the rule.
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// HitCount is the number of consecutive observations reaching the value of the rule, compared with
+	// AvoidanceThreshold while the rule is not triggered.
+	// +optional
+	HitCount int32 `json:"hitCount,omitempty"`
+	// RestoreCount is the number of consecutive observations below the value of the rule, compared with
+	// RestoreThreshold while the rule is triggered.
+	// +optional
+	RestoreCount int32 `json:"restoreCount,omitempty"`
+	// LastTriggeredTime is the last time the rule was triggered.
+	// +optional
+	LastTriggeredTime *metav1.Time `json:"lastTriggeredTime,omitempty"`
+	// LastRestoredTime is the last time the rule was restored.
+	// +optional
+	LastRestoredTime *metav1.Time `json:"lastRestoredTime,omitempty"`
+	// ActionName is the name of the avoidance action of the rule.
+	// +optional
+	ActionName string `json:"actionName,omitempty"`
+	// Preview means the action is only previewed, not taken, as the strategy of the rule is Preview.
+	// +optional
+	Preview bool `json:"preview,omitempty"`
+}
+
+// RuleTransition is a transition of a rule on a node.
+type RuleTransition struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+	// RuleName is the name of the rule.
+	RuleName string `json:"ruleName"`
+	// State is the state the rule transitioned to: Triggered when its action is taken or previewed,
+	// Normal when it is restored.
+	State RuleState `json:"state"`
+	// Time is the time of the transition.
+	Time metav1.Time `json:"time"`
+	// Value is the value of the metric that caused the transition.
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// ActionName is the name of the avoidance action of the rule.
+	// +optional
+	ActionName string `json:"actionName,omitempty"`
+	// Preview means the action is only previewed, not taken.
+	// +optional
+	Preview bool `json:"preview,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,8 @@
+package v1alpha1
+
+const (
+	// AnnotationPodQOSSources is the annotation key recording where the effective ResourceQOS of a pod
+	// comes from. Its value is a JSON object from the path of every set ResourceQOS field, such as
+	// "cpuQOS.cpuPriority", to the name of the PodQOS the field was taken from.
+	AnnotationPodQOSSources = "ensurance.crane.io/pod-qos-sources"
+)
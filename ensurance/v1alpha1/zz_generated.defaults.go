@@ -0,0 +1,64 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by defaulter-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&AvoidanceAction{}, func(obj interface{}) { SetObjectDefaults_AvoidanceAction(obj.(*AvoidanceAction)) })
+	scheme.AddTypeDefaultingFunc(&AvoidanceActionList{}, func(obj interface{}) { SetObjectDefaults_AvoidanceActionList(obj.(*AvoidanceActionList)) })
+	scheme.AddTypeDefaultingFunc(&NodeQOS{}, func(obj interface{}) { SetObjectDefaults_NodeQOS(obj.(*NodeQOS)) })
+	scheme.AddTypeDefaultingFunc(&NodeQOSList{}, func(obj interface{}) { SetObjectDefaults_NodeQOSList(obj.(*NodeQOSList)) })
+	scheme.AddTypeDefaultingFunc(&PodQOS{}, func(obj interface{}) { SetObjectDefaults_PodQOS(obj.(*PodQOS)) })
+	scheme.AddTypeDefaultingFunc(&PodQOSList{}, func(obj interface{}) { SetObjectDefaults_PodQOSList(obj.(*PodQOSList)) })
+	return nil
+}
+
+func SetObjectDefaults_AvoidanceAction(in *AvoidanceAction) {
+	SetDefaults_AvoidanceActionSpec(&in.Spec)
+}
+
+func SetObjectDefaults_AvoidanceActionList(in *AvoidanceActionList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_AvoidanceAction(a)
+	}
+}
+
+func SetObjectDefaults_NodeQOS(in *NodeQOS) {
+	if in.Spec.NodeQualityProbe.NodeLocalGet != nil {
+		SetDefaults_NodeLocalGet(in.Spec.NodeQualityProbe.NodeLocalGet)
+	}
+	for i := range in.Spec.Rules {
+		a := &in.Spec.Rules[i]
+		SetDefaults_Rule(a)
+	}
+}
+
+func SetObjectDefaults_NodeQOSList(in *NodeQOSList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_NodeQOS(a)
+	}
+}
+
+func SetObjectDefaults_PodQOS(in *PodQOS) {
+	if in.Spec.ResourceQOS.MemoryQOS != nil {
+		SetDefaults_MemoryCompression(&in.Spec.ResourceQOS.MemoryQOS.MemoryCompression)
+	}
+}
+
+func SetObjectDefaults_PodQOSList(in *PodQOSList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_PodQOS(a)
+	}
+}
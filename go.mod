@@ -5,6 +5,7 @@ go 1.16
 require (
 	github.com/onsi/ginkgo v1.16.5
 	github.com/onsi/gomega v1.15.0 // indirect
+	github.com/robfig/cron/v3 v3.0.1
 	golang.org/x/sys v0.0.0-20210817190340-bfb29a6856f2 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	k8s.io/api v0.22.3
@@ -14,5 +15,5 @@ require (
 	k8s.io/code-generator v0.22.3
 	k8s.io/kube-openapi v0.0.0-20210421082810-95288971da7e
 	k8s.io/metrics v0.22.1
-
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -31,6 +31,14 @@ bash "${CODEGEN_PKG}"/generate-groups.sh "deepcopy" \
   --output-base "$SCRIPT_ROOT" \
   --go-header-file "${SCRIPT_ROOT}/hack/boilerplate/boilerplate.go.txt"
 
+bash "${CODEGEN_PKG}"/generate-internal-groups.sh "defaulter" \
+  github.com/gocrane/api/pkg/generated \
+  github.com/gocrane/api \
+  github.com/gocrane/api \
+  "autoscaling:v1alpha1 ensurance:v1alpha1 prediction:v1alpha1 analysis:v1alpha1 topology:v1alpha1" \
+  --output-base "$SCRIPT_ROOT" \
+  --go-header-file "${SCRIPT_ROOT}/hack/boilerplate/boilerplate.go.txt"
+
 cp -r $SCRIPT_ROOT/github.com/gocrane/api/* $SCRIPT_ROOT
 
 rm -rf $SCRIPT_ROOT/github.com
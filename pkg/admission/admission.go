@@ -0,0 +1,112 @@
+// Package admission serves the validations of every API group of this module
+// as validating admission webhooks.
+//
+// Each kind is served on its own path, "/validate-" followed by the lower case
+// kind, e.g. /validate-timeseriesprediction.
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateFunc validates an object decoded by a Validator.
+type ValidateFunc func(obj runtime.Object) field.ErrorList
+
+// Validator admits or denies create and update requests of a single kind.
+type Validator struct {
+	kind      schema.GroupVersionKind
+	newObject func() runtime.Object
+	validate  ValidateFunc
+}
+
+// NewValidator returns a Validator for kind. newObject returns an empty object
+// of kind which the request object is decoded into before validate is called.
+func NewValidator(kind schema.GroupVersionKind, newObject func() runtime.Object, validate ValidateFunc) *Validator {
+	return &Validator{
+		kind:      kind,
+		newObject: newObject,
+		validate:  validate,
+	}
+}
+
+// Kind returns the kind validated by v.
+func (v *Validator) Kind() schema.GroupVersionKind {
+	return v.kind
+}
+
+// Path returns the path v is served on.
+func (v *Validator) Path() string {
+	return "/validate-" + strings.ToLower(v.kind.Kind)
+}
+
+// Review returns the admission response to request. Only create and update requests are validated.
+func (v *Validator) Review(request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	response := &admissionv1.AdmissionResponse{UID: request.UID, Allowed: true}
+	if request.Operation != admissionv1.Create && request.Operation != admissionv1.Update {
+		return response
+	}
+
+	obj := v.newObject()
+	if err := json.Unmarshal(request.Object.Raw, obj); err != nil {
+		response.Allowed = false
+		response.Result = &apierrors.NewBadRequest(fmt.Sprintf("failed to decode %s: %v", v.kind.Kind, err)).ErrStatus
+		return response
+	}
+
+	if allErrs := v.validate(obj); len(allErrs) > 0 {
+		response.Allowed = false
+		response.Result = &apierrors.NewInvalid(v.kind.GroupKind(), request.Name, allErrs).ErrStatus
+	}
+	return response
+}
+
+// ServeHTTP handles an admission.k8s.io/v1 AdmissionReview.
+func (v *Validator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "admission review has no request", http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		},
+		Response: v.Review(review.Request),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// Install registers every validator returned by Validators on mux.
+func Install(mux *http.ServeMux) {
+	for _, v := range Validators() {
+		mux.Handle(v.Path(), v)
+	}
+}
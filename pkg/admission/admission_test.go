@@ -0,0 +1,67 @@
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+func TestServeHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	Install(mux)
+
+	tests := []struct {
+		name        string
+		cpuSet      string
+		wantAllowed bool
+	}{
+		{name: "valid cpuset", cpuSet: "exclusive", wantAllowed: true},
+		{name: "invalid cpuset", cpuSet: "dedicated", wantAllowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podQOS := &ensuranceapi.PodQOS{
+				Spec: ensuranceapi.PodQOSSpec{
+					ResourceQOS: ensuranceapi.ResourceQOS{
+						CPUQOS: &ensuranceapi.CPUQOS{CPUSet: ensuranceapi.CPUSet{CPUSet: tt.cpuSet}},
+					},
+				},
+			}
+			raw, _ := json.Marshal(podQOS)
+			body, _ := json.Marshal(&admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					UID:       types.UID("uid"),
+					Name:      "pod-qos",
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/validate-podqos", bytes.NewReader(body)))
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("got status %d: %s", recorder.Code, recorder.Body.String())
+			}
+
+			review := &admissionv1.AdmissionReview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), review); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if review.Response.UID != "uid" {
+				t.Errorf("got uid %q, want %q", review.Response.UID, "uid")
+			}
+			if review.Response.Allowed != tt.wantAllowed {
+				t.Errorf("got allowed %v, want %v: %v", review.Response.Allowed, tt.wantAllowed, review.Response.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package admission
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	analysisapi "github.com/gocrane/api/analysis/v1alpha1"
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	co2eapi "github.com/gocrane/api/co2e/v1alpha1"
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+	analysisvalidation "github.com/gocrane/api/pkg/analysis/validation"
+	autoscalingvalidation "github.com/gocrane/api/pkg/autoscaling/validation"
+	co2evalidation "github.com/gocrane/api/pkg/co2e/validation"
+	ensurancevalidation "github.com/gocrane/api/pkg/ensurance/validation"
+	predictionvalidation "github.com/gocrane/api/pkg/prediction/validation"
+	topologyvalidation "github.com/gocrane/api/pkg/topology/validation"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+	topologyapi "github.com/gocrane/api/topology/v1alpha1"
+)
+
+// Validators returns a Validator for every kind of every API group of this module.
+func Validators() []*Validator {
+	return []*Validator{
+		// analysis
+		NewValidator(analysisapi.SchemeGroupVersion.WithKind("Recommendation"),
+			func() runtime.Object { return &analysisapi.Recommendation{} },
+			func(obj runtime.Object) field.ErrorList {
+				return analysisvalidation.ValidateRecommendation(obj.(*analysisapi.Recommendation))
+			}),
+		NewValidator(analysisapi.SchemeGroupVersion.WithKind("Analytics"),
+			func() runtime.Object { return &analysisapi.Analytics{} },
+			func(obj runtime.Object) field.ErrorList {
+				return analysisvalidation.ValidateAnalytics(obj.(*analysisapi.Analytics))
+			}),
+		NewValidator(analysisapi.SchemeGroupVersion.WithKind("RecommendationRule"),
+			func() runtime.Object { return &analysisapi.RecommendationRule{} },
+			func(obj runtime.Object) field.ErrorList {
+				return analysisvalidation.ValidateRecommendationRule(obj.(*analysisapi.RecommendationRule))
+			}),
+		NewValidator(analysisapi.SchemeGroupVersion.WithKind("ConfigSet"),
+			func() runtime.Object { return &analysisapi.ConfigSet{} },
+			func(obj runtime.Object) field.ErrorList {
+				return analysisvalidation.ValidateConfigSet(obj.(*analysisapi.ConfigSet))
+			}),
+
+		// autoscaling
+		NewValidator(autoscalingapi.SchemeGroupVersion.WithKind("EffectiveHorizontalPodAutoscaler"),
+			func() runtime.Object { return &autoscalingapi.EffectiveHorizontalPodAutoscaler{} },
+			func(obj runtime.Object) field.ErrorList {
+				return autoscalingvalidation.ValidateEffectiveHorizontalPodAutoscaler(obj.(*autoscalingapi.EffectiveHorizontalPodAutoscaler))
+			}),
+		NewValidator(autoscalingapi.SchemeGroupVersion.WithKind("EffectiveVerticalPodAutoscaler"),
+			func() runtime.Object { return &autoscalingapi.EffectiveVerticalPodAutoscaler{} },
+			func(obj runtime.Object) field.ErrorList {
+				return autoscalingvalidation.ValidateEffectiveVerticalPodAutoscaler(obj.(*autoscalingapi.EffectiveVerticalPodAutoscaler))
+			}),
+		NewValidator(autoscalingapi.SchemeGroupVersion.WithKind("Substitute"),
+			func() runtime.Object { return &autoscalingapi.Substitute{} },
+			func(obj runtime.Object) field.ErrorList {
+				return autoscalingvalidation.ValidateSubstitute(obj.(*autoscalingapi.Substitute))
+			}),
+
+		// co2e
+		NewValidator(co2eapi.SchemeGroupVersion.WithKind("CloudCarbonFootprint"),
+			func() runtime.Object { return &co2eapi.CloudCarbonFootprint{} },
+			func(obj runtime.Object) field.ErrorList {
+				return co2evalidation.ValidateCloudCarbonFootprint(obj.(*co2eapi.CloudCarbonFootprint))
+			}),
+
+		// ensurance
+		NewValidator(ensuranceapi.SchemeGroupVersion.WithKind("PodQOS"),
+			func() runtime.Object { return &ensuranceapi.PodQOS{} },
+			func(obj runtime.Object) field.ErrorList {
+				return ensurancevalidation.ValidatePodQOS(obj.(*ensuranceapi.PodQOS))
+			}),
+		NewValidator(ensuranceapi.SchemeGroupVersion.WithKind("NodeQOS"),
+			func() runtime.Object { return &ensuranceapi.NodeQOS{} },
+			func(obj runtime.Object) field.ErrorList {
+				return ensurancevalidation.ValidateNodeQOS(obj.(*ensuranceapi.NodeQOS))
+			}),
+		NewValidator(ensuranceapi.SchemeGroupVersion.WithKind("AvoidanceAction"),
+			func() runtime.Object { return &ensuranceapi.AvoidanceAction{} },
+			func(obj runtime.Object) field.ErrorList {
+				return ensurancevalidation.ValidateAvoidanceAction(obj.(*ensuranceapi.AvoidanceAction))
+			}),
+
+		// prediction
+		NewValidator(predictionapi.SchemeGroupVersion.WithKind("TimeSeriesPrediction"),
+			func() runtime.Object { return &predictionapi.TimeSeriesPrediction{} },
+			func(obj runtime.Object) field.ErrorList {
+				return predictionvalidation.ValidateTimeSeriesPrediction(obj.(*predictionapi.TimeSeriesPrediction))
+			}),
+		NewValidator(predictionapi.SchemeGroupVersion.WithKind("ClusterNodePrediction"),
+			func() runtime.Object { return &predictionapi.ClusterNodePrediction{} },
+			func(obj runtime.Object) field.ErrorList {
+				return predictionvalidation.ValidateClusterNodePrediction(obj.(*predictionapi.ClusterNodePrediction))
+			}),
+		NewValidator(predictionapi.SchemeGroupVersion.WithKind("PodGroupPrediction"),
+			func() runtime.Object { return &predictionapi.PodGroupPrediction{} },
+			func(obj runtime.Object) field.ErrorList {
+				return predictionvalidation.ValidatePodGroupPrediction(obj.(*predictionapi.PodGroupPrediction))
+			}),
+		NewValidator(predictionapi.SchemeGroupVersion.WithKind("NodePrediction"),
+			func() runtime.Object { return &predictionapi.NodePrediction{} },
+			func(obj runtime.Object) field.ErrorList {
+				return predictionvalidation.ValidateNodePrediction(obj.(*predictionapi.NodePrediction))
+			}),
+
+		// topology
+		NewValidator(topologyapi.SchemeGroupVersion.WithKind("NodeResourceTopology"),
+			func() runtime.Object { return &topologyapi.NodeResourceTopology{} },
+			func(obj runtime.Object) field.ErrorList {
+				return topologyvalidation.ValidateNodeResourceTopology(obj.(*topologyapi.NodeResourceTopology))
+			}),
+	}
+}
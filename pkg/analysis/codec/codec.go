@@ -0,0 +1,120 @@
+// Package codec converts between the typed ProposedRecommendation and the
+// RecommendedValue string stored in a Recommendation status.
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+)
+
+// Format is the serialization format of a RecommendedValue.
+type Format string
+
+const (
+	// FormatYAML serializes the proposal as YAML, which is what recommenders write by default.
+	FormatYAML Format = "yaml"
+	// FormatJSON serializes the proposal as JSON.
+	FormatJSON Format = "json"
+)
+
+var (
+	// ErrEmptyValue is returned when a RecommendedValue or a proposal carries no recommendation.
+	ErrEmptyValue = errors.New("empty recommended value")
+	// ErrTypeMismatch is returned when a proposal does not fit the RecommendationSpec.Type.
+	ErrTypeMismatch = errors.New("recommended value does not match recommendation type")
+	// ErrUnknownType is returned for an AnalysisType the codec does not know.
+	ErrUnknownType = errors.New("unknown recommendation type")
+	// ErrUnknownFormat is returned for a Format the codec does not know.
+	ErrUnknownFormat = errors.New("unknown recommended value format")
+)
+
+// Marshal serializes proposal in the given format after checking it against recommendationType.
+func Marshal(recommendationType analysisv1alpha1.AnalysisType, proposal *analysisv1alpha1.ProposedRecommendation, format Format) (string, error) {
+	if err := Check(recommendationType, proposal); err != nil {
+		return "", err
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch format {
+	case FormatYAML:
+		data, err = yaml.Marshal(proposal)
+	case FormatJSON:
+		data, err = json.Marshal(proposal)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Unmarshal parses value, either YAML or JSON, and checks the result against recommendationType.
+// Unknown fields are rejected.
+func Unmarshal(recommendationType analysisv1alpha1.AnalysisType, value string) (*analysisv1alpha1.ProposedRecommendation, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, ErrEmptyValue
+	}
+
+	proposal := &analysisv1alpha1.ProposedRecommendation{}
+	if err := yaml.UnmarshalStrict([]byte(value), proposal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recommended value: %w", err)
+	}
+	if err := Check(recommendationType, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// Encode sets the RecommendedValue of recommendation to proposal, serialized in the given format.
+func Encode(recommendation *analysisv1alpha1.Recommendation, proposal *analysisv1alpha1.ProposedRecommendation, format Format) error {
+	value, err := Marshal(recommendation.Spec.Type, proposal, format)
+	if err != nil {
+		return err
+	}
+	recommendation.Status.RecommendedValue = value
+	return nil
+}
+
+// Decode returns the proposal stored in the RecommendedValue of recommendation.
+func Decode(recommendation *analysisv1alpha1.Recommendation) (*analysisv1alpha1.ProposedRecommendation, error) {
+	return Unmarshal(recommendation.Spec.Type, recommendation.Status.RecommendedValue)
+}
+
+// Check verifies that proposal holds a recommendation, and only recommendations, of recommendationType.
+// Replicas recommendations carry EffectiveHPA and/or ReplicasRecommendation,
+// Resource recommendations carry ResourceRequest.
+func Check(recommendationType analysisv1alpha1.AnalysisType, proposal *analysisv1alpha1.ProposedRecommendation) error {
+	if proposal == nil {
+		return ErrEmptyValue
+	}
+
+	replicas := proposal.EffectiveHPA != nil || proposal.ReplicasRecommendation != nil
+	resource := proposal.ResourceRequest != nil
+	if !replicas && !resource {
+		return ErrEmptyValue
+	}
+
+	switch recommendationType {
+	case analysisv1alpha1.AnalysisTypeReplicas:
+		if resource {
+			return fmt.Errorf("%w: type %s must not contain resourceRequest", ErrTypeMismatch, recommendationType)
+		}
+	case analysisv1alpha1.AnalysisTypeResource:
+		if replicas {
+			return fmt.Errorf("%w: type %s must not contain effectiveHPA or replicasRecommendation", ErrTypeMismatch, recommendationType)
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownType, recommendationType)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+)
+
+func TestRoundTrip(t *testing.T) {
+	replicas := int32(3)
+	proposals := map[analysisv1alpha1.AnalysisType]*analysisv1alpha1.ProposedRecommendation{
+		analysisv1alpha1.AnalysisTypeReplicas: {
+			ReplicasRecommendation: &analysisv1alpha1.ReplicasRecommendation{Replicas: &replicas},
+		},
+		analysisv1alpha1.AnalysisTypeResource: {
+			ResourceRequest: &analysisv1alpha1.ResourceRequestRecommendation{
+				Containers: []analysisv1alpha1.ContainerRecommendation{
+					{ContainerName: "nginx", Target: analysisv1alpha1.ResourceList{"cpu": "250m", "memory": "128Mi"}},
+				},
+			},
+		},
+	}
+
+	for recommendationType, proposal := range proposals {
+		for _, format := range []Format{FormatYAML, FormatJSON} {
+			value, err := Marshal(recommendationType, proposal, format)
+			if err != nil {
+				t.Fatalf("Marshal(%s, %s) failed: %v", recommendationType, format, err)
+			}
+			got, err := Unmarshal(recommendationType, value)
+			if err != nil {
+				t.Fatalf("Unmarshal(%s, %s) failed: %v", recommendationType, format, err)
+			}
+			if !reflect.DeepEqual(got, proposal) {
+				t.Errorf("round trip of %s in %s = %+v, want %+v", recommendationType, format, got, proposal)
+			}
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name               string
+		recommendationType analysisv1alpha1.AnalysisType
+		value              string
+		want               error
+	}{
+		{
+			name:               "empty",
+			recommendationType: analysisv1alpha1.AnalysisTypeReplicas,
+			value:              " ",
+			want:               ErrEmptyValue,
+		},
+		{
+			name:               "resource value for replicas type",
+			recommendationType: analysisv1alpha1.AnalysisTypeReplicas,
+			value:              "resourceRequest:\n  containers:\n  - containerName: nginx\n",
+			want:               ErrTypeMismatch,
+		},
+		{
+			name:               "replicas value for resource type",
+			recommendationType: analysisv1alpha1.AnalysisTypeResource,
+			value:              `{"replicasRecommendation":{"replicas":2}}`,
+			want:               ErrTypeMismatch,
+		},
+		{
+			name:               "unknown type",
+			recommendationType: "HPA",
+			value:              "replicasRecommendation:\n  replicas: 2\n",
+			want:               ErrUnknownType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Unmarshal(tt.recommendationType, tt.value); !errors.Is(err, tt.want) {
+				t.Errorf("Unmarshal() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Unmarshal(analysisv1alpha1.AnalysisTypeReplicas, "replicas: 2\n"); err == nil {
+		t.Errorf("Unmarshal() of an unknown field succeeded, want error")
+	}
+}
@@ -0,0 +1,101 @@
+// Package configset resolves the recommendation properties that a ConfigSet
+// assigns to a single target.
+package configset
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+)
+
+// Match levels of a Target. A Target matches with the sum of the levels of its
+// non-empty fields, so a name always outweighs a kind and a kind always outweighs
+// a namespace, whatever other fields are set.
+const (
+	// MatchLevelDefault is the level of a Config without targets, or of a Target without any field set.
+	MatchLevelDefault = 0
+	// MatchLevelNamespace is added when the Target namespace equals the target namespace.
+	MatchLevelNamespace = 1
+	// MatchLevelKind is added when the Target kind equals the target kind.
+	MatchLevelKind = 2
+	// MatchLevelName is added when the Target name equals the target name.
+	MatchLevelName = 4
+)
+
+// Match reports whether selector selects target and the level of the match.
+// Every non-empty field of selector must be equal to the same field of target.
+func Match(selector, target analysisv1alpha1.Target) (int, bool) {
+	level := MatchLevelDefault
+	if selector.Namespace != "" {
+		if selector.Namespace != target.Namespace {
+			return 0, false
+		}
+		level += MatchLevelNamespace
+	}
+	if selector.Kind != "" {
+		if selector.Kind != target.Kind {
+			return 0, false
+		}
+		level += MatchLevelKind
+	}
+	if selector.Name != "" {
+		if selector.Name != target.Name {
+			return 0, false
+		}
+		level += MatchLevelName
+	}
+	return level, true
+}
+
+// MatchConfig reports whether config applies to target and the level of its best matching Target.
+// A Config without targets is the global default and applies to every target.
+func MatchConfig(config *analysisv1alpha1.Config, target analysisv1alpha1.Target) (int, bool) {
+	if len(config.Targets) == 0 {
+		return MatchLevelDefault, true
+	}
+	best, matched := 0, false
+	for _, t := range config.Targets {
+		if level, ok := Match(t, target); ok && (!matched || level > best) {
+			best, matched = level, true
+		}
+	}
+	return best, matched
+}
+
+// Resolve returns the properties the ConfigSet assigns to target.
+// The properties of every matching Config are merged key by key, and a key set
+// by a Config with a higher match level overrides the same key of a lower one:
+// exact name over kind over namespace over the global default.
+// Configs of the same level are applied in order, so the later one wins.
+// The returned map is never nil.
+func Resolve(configSet *analysisv1alpha1.ConfigSet, target analysisv1alpha1.Target) map[string]string {
+	properties := map[string]string{}
+	if configSet == nil {
+		return properties
+	}
+
+	levels := map[string]int{}
+	for i := range configSet.Configs {
+		level, ok := MatchConfig(&configSet.Configs[i], target)
+		if !ok {
+			continue
+		}
+		for k, v := range configSet.Configs[i].Properties {
+			if current, found := levels[k]; found && current > level {
+				continue
+			}
+			levels[k] = level
+			properties[k] = v
+		}
+	}
+	return properties
+}
+
+// TargetFromObjectReference returns the Target describing ref.
+func TargetFromObjectReference(ref corev1.ObjectReference) analysisv1alpha1.Target {
+	return analysisv1alpha1.Target{
+		Namespace: ref.Namespace,
+		Kind:      ref.Kind,
+		Name:      ref.Name,
+	}
+}
@@ -0,0 +1,101 @@
+package configset
+
+import (
+	"reflect"
+	"testing"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+)
+
+func TestResolve(t *testing.T) {
+	configSet := &analysisv1alpha1.ConfigSet{
+		Configs: []analysisv1alpha1.Config{
+			{
+				Targets: []analysisv1alpha1.Target{{Name: "nginx"}},
+				Properties: map[string]string{
+					"cpu-request-percentile": "0.99",
+				},
+			},
+			{
+				Targets: []analysisv1alpha1.Target{{Kind: "Deployment"}},
+				Properties: map[string]string{
+					"cpu-request-percentile": "0.95",
+					"cpu-request-margin":     "0.2",
+				},
+			},
+			{
+				Targets: []analysisv1alpha1.Target{{Namespace: "default"}},
+				Properties: map[string]string{
+					"cpu-request-margin": "0.3",
+					"memory-margin":      "0.1",
+				},
+			},
+			{
+				Properties: map[string]string{
+					"cpu-request-percentile": "0.9",
+					"memory-margin":          "0.15",
+					"history-length":         "168h",
+				},
+			},
+			{
+				Targets: []analysisv1alpha1.Target{{Namespace: "kube-system"}},
+				Properties: map[string]string{
+					"history-length": "24h",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		target analysisv1alpha1.Target
+		want   map[string]string
+	}{
+		{
+			name:   "exact name wins",
+			target: analysisv1alpha1.Target{Namespace: "default", Kind: "Deployment", Name: "nginx"},
+			want: map[string]string{
+				"cpu-request-percentile": "0.99",
+				"cpu-request-margin":     "0.2",
+				"memory-margin":          "0.1",
+				"history-length":         "168h",
+			},
+		},
+		{
+			name:   "kind over namespace",
+			target: analysisv1alpha1.Target{Namespace: "default", Kind: "Deployment", Name: "redis"},
+			want: map[string]string{
+				"cpu-request-percentile": "0.95",
+				"cpu-request-margin":     "0.2",
+				"memory-margin":          "0.1",
+				"history-length":         "168h",
+			},
+		},
+		{
+			name:   "global default only",
+			target: analysisv1alpha1.Target{Namespace: "prod", Kind: "StatefulSet", Name: "redis"},
+			want: map[string]string{
+				"cpu-request-percentile": "0.9",
+				"memory-margin":          "0.15",
+				"history-length":         "168h",
+			},
+		},
+		{
+			name:   "namespace over global default",
+			target: analysisv1alpha1.Target{Namespace: "kube-system", Kind: "DaemonSet", Name: "kube-proxy"},
+			want: map[string]string{
+				"cpu-request-percentile": "0.9",
+				"memory-margin":          "0.15",
+				"history-length":         "24h",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(configSet, tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Package patch builds the workload patches that adopt a Recommendation.
+//
+// Built-in workloads (Deployment, StatefulSet, DaemonSet, ReplicaSet, Job and
+// CronJob) are patched with strategic merge patches. Custom workloads given as
+// unstructured objects do not support strategic merge, so their replicas are
+// patched with a JSON merge patch and their containers with a JSON patch.
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+	"github.com/gocrane/api/pkg/analysis/codec"
+)
+
+var (
+	// ErrUnsupportedTarget is returned when the target workload cannot receive the recommendation.
+	ErrUnsupportedTarget = errors.New("unsupported patch target")
+	// ErrTargetMismatch is returned when the target workload is not the target of the recommendation.
+	ErrTargetMismatch = errors.New("target does not match recommendation targetRef")
+	// ErrContainerNotFound is returned when a recommended container does not exist in the target workload.
+	ErrContainerNotFound = errors.New("container not found in target")
+	// ErrNothingToPatch is returned when the recommendation has no value that can be applied to a workload.
+	ErrNothingToPatch = errors.New("recommendation has nothing to patch")
+)
+
+// Patch is a patch of a single workload.
+type Patch struct {
+	// Type is the patch type to send along with Data.
+	Type types.PatchType
+	// Data is the serialized patch.
+	Data []byte
+}
+
+// Build returns the patch that applies the RecommendedValue of recommendation to target.
+// target must be the workload referenced by recommendation.Spec.TargetRef, either typed or unstructured.
+func Build(recommendation *analysisv1alpha1.Recommendation, target runtime.Object) (*Patch, error) {
+	proposal, err := codec.Decode(recommendation)
+	if err != nil {
+		return nil, err
+	}
+	return BuildFromProposal(recommendation.Spec.TargetRef, proposal, target)
+}
+
+// BuildFromProposal returns the patch that applies proposal to target.
+// Empty fields of targetRef are not checked against target. A nil proposal has nothing to patch.
+func BuildFromProposal(targetRef corev1.ObjectReference, proposal *analysisv1alpha1.ProposedRecommendation, target runtime.Object) (*Patch, error) {
+	if proposal == nil {
+		return nil, ErrNothingToPatch
+	}
+	w, err := newWorkload(target)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTargetRef(targetRef, w); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case proposal.ResourceRequest != nil:
+		return buildResourcePatch(w, proposal.ResourceRequest)
+	case proposal.ReplicasRecommendation != nil && proposal.ReplicasRecommendation.Replicas != nil:
+		return buildReplicasPatch(w, *proposal.ReplicasRecommendation.Replicas)
+	default:
+		return nil, ErrNothingToPatch
+	}
+}
+
+func checkTargetRef(ref corev1.ObjectReference, w *workload) error {
+	if (ref.Kind != "" && ref.Kind != w.kind) ||
+		(ref.Namespace != "" && ref.Namespace != w.namespace) ||
+		(ref.Name != "" && ref.Name != w.name) {
+		return fmt.Errorf("%w: want %s %s/%s, got %s %s/%s", ErrTargetMismatch,
+			ref.Kind, ref.Namespace, ref.Name, w.kind, w.namespace, w.name)
+	}
+	return nil
+}
+
+func buildReplicasPatch(w *workload, replicas int32) (*Patch, error) {
+	if !w.scalable {
+		return nil, fmt.Errorf("%w: %s has no replicas", ErrUnsupportedTarget, w.kind)
+	}
+
+	data, err := json.Marshal(analysisv1alpha1.PatchReplicas{
+		Spec: analysisv1alpha1.PatchReplicasSpec{Replicas: &replicas},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	patchType := types.StrategicMergePatchType
+	if !w.builtin {
+		patchType = types.MergePatchType
+	}
+	return &Patch{Type: patchType, Data: data}, nil
+}
+
+func buildResourcePatch(w *workload, recommendation *analysisv1alpha1.ResourceRequestRecommendation) (*Patch, error) {
+	if len(recommendation.Containers) == 0 {
+		return nil, ErrNothingToPatch
+	}
+
+	existing, err := w.containers()
+	if err != nil {
+		return nil, err
+	}
+
+	if w.builtin {
+		return buildStrategicResourcePatch(w, existing, recommendation)
+	}
+	return buildJSONResourcePatch(w, existing, recommendation)
+}
+
+func buildStrategicResourcePatch(w *workload, existing []interface{}, recommendation *analysisv1alpha1.ResourceRequestRecommendation) (*Patch, error) {
+	podSpec := analysisv1alpha1.PatchResourcePodSpec{}
+	for _, c := range recommendation.Containers {
+		if containerIndex(existing, c.ContainerName) < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, c.ContainerName)
+		}
+		requests, err := parseResourceList(c.Target)
+		if err != nil {
+			return nil, fmt.Errorf("container %s: %w", c.ContainerName, err)
+		}
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{
+			Name:      c.ContainerName,
+			Resources: corev1.ResourceRequirements{Requests: requests},
+		})
+	}
+
+	// Wrap the pod template into the kind-specific path, e.g. spec.jobTemplate.spec.template for a CronJob.
+	var patch interface{} = analysisv1alpha1.PatchResourcePodTemplateSpec{Spec: podSpec}
+	for i := len(w.templatePath) - 1; i >= 0; i-- {
+		patch = map[string]interface{}{w.templatePath[i]: patch}
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return &Patch{Type: types.StrategicMergePatchType, Data: data}, nil
+}
+
+// jsonPatchOperation is a single RFC 6902 operation.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func buildJSONResourcePatch(w *workload, existing []interface{}, recommendation *analysisv1alpha1.ResourceRequestRecommendation) (*Patch, error) {
+	var operations []jsonPatchOperation
+	for _, c := range recommendation.Containers {
+		index := containerIndex(existing, c.ContainerName)
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, c.ContainerName)
+		}
+		requests, err := parseResourceList(c.Target)
+		if err != nil {
+			return nil, fmt.Errorf("container %s: %w", c.ContainerName, err)
+		}
+
+		container, _ := existing[index].(map[string]interface{})
+		resources, hasResources := container["resources"].(map[string]interface{})
+		_, hasRequests := resources["requests"].(map[string]interface{})
+		path := jsonPointer(append(w.templatePath, "spec", "containers", fmt.Sprint(index), "resources")...)
+
+		switch {
+		case !hasResources:
+			operations = append(operations, jsonPatchOperation{Op: "add", Path: path, Value: corev1.ResourceRequirements{Requests: requests}})
+		case !hasRequests:
+			operations = append(operations, jsonPatchOperation{Op: "add", Path: path + "/requests", Value: requests})
+		default:
+			for _, name := range sortedResourceNames(requests) {
+				q := requests[name]
+				operations = append(operations, jsonPatchOperation{Op: "add", Path: path + "/requests/" + escapeJSONPointer(string(name)), Value: q.String()})
+			}
+		}
+	}
+
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+	return &Patch{Type: types.JSONPatchType, Data: data}, nil
+}
+
+func containerIndex(containers []interface{}, name string) int {
+	for i, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok && container["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseResourceList(list analysisv1alpha1.ResourceList) (corev1.ResourceList, error) {
+	if len(list) == 0 {
+		return nil, ErrNothingToPatch
+	}
+	resources := corev1.ResourceList{}
+	for name, value := range list {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for %s: %w", value, name, err)
+		}
+		resources[name] = q
+	}
+	return resources, nil
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func jsonPointer(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(escapeJSONPointer(token))
+	}
+	return b.String()
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
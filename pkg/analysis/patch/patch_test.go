@@ -0,0 +1,158 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	analysisv1alpha1 "github.com/gocrane/api/analysis/v1alpha1"
+)
+
+func newRecommendation(kind string, recommendationType analysisv1alpha1.AnalysisType, value string) *analysisv1alpha1.Recommendation {
+	return &analysisv1alpha1.Recommendation{
+		Spec: analysisv1alpha1.RecommendationSpec{
+			TargetRef: corev1.ObjectReference{Kind: kind, Namespace: "default", Name: "app"},
+			Type:      recommendationType,
+		},
+		Status: analysisv1alpha1.RecommendationStatus{
+			RecommendationContent: analysisv1alpha1.RecommendationContent{RecommendedValue: value},
+		},
+	}
+}
+
+func newPodTemplate() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Namespace: "default", Name: "app"}
+	replicasValue := "replicasRecommendation:\n  replicas: 3\n"
+	resourceValue := "resourceRequest:\n  containers:\n  - containerName: app\n    target:\n      cpu: 500m\n      memory: 256Mi\n"
+
+	custom := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps.kruise.io/v1alpha1",
+		"kind":       "CloneSet",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": "app"},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "sidecar"},
+						map[string]interface{}{"name": "app", "resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "1"}}},
+					},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name           string
+		recommendation *analysisv1alpha1.Recommendation
+		target         runtime.Object
+		wantType       types.PatchType
+		wantData       string
+		wantErr        error
+	}{
+		{
+			name:           "deployment replicas",
+			recommendation: newRecommendation("Deployment", analysisv1alpha1.AnalysisTypeReplicas, replicasValue),
+			target:         &appsv1.Deployment{ObjectMeta: objectMeta},
+			wantType:       types.StrategicMergePatchType,
+			wantData:       `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:           "daemonset has no replicas",
+			recommendation: newRecommendation("DaemonSet", analysisv1alpha1.AnalysisTypeReplicas, replicasValue),
+			target:         &appsv1.DaemonSet{ObjectMeta: objectMeta},
+			wantErr:        ErrUnsupportedTarget,
+		},
+		{
+			name:           "statefulset resource",
+			recommendation: newRecommendation("StatefulSet", analysisv1alpha1.AnalysisTypeResource, resourceValue),
+			target:         &appsv1.StatefulSet{ObjectMeta: objectMeta, Spec: appsv1.StatefulSetSpec{Template: newPodTemplate()}},
+			wantType:       types.StrategicMergePatchType,
+			wantData:       `{"spec":{"template":{"spec":{"containers":[{"name":"app","resources":{"requests":{"cpu":"500m","memory":"256Mi"}}}]}}}}`,
+		},
+		{
+			name:           "cronjob resource",
+			recommendation: newRecommendation("CronJob", analysisv1alpha1.AnalysisTypeResource, resourceValue),
+			target: &batchv1.CronJob{ObjectMeta: objectMeta, Spec: batchv1.CronJobSpec{
+				JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: newPodTemplate()}},
+			}},
+			wantType: types.StrategicMergePatchType,
+			wantData: `{"spec":{"jobTemplate":{"spec":{"template":{"spec":{"containers":[{"name":"app","resources":{"requests":{"cpu":"500m","memory":"256Mi"}}}]}}}}}}`,
+		},
+		{
+			name:           "unknown container",
+			recommendation: newRecommendation("Job", analysisv1alpha1.AnalysisTypeResource, resourceValue),
+			target:         &batchv1.Job{ObjectMeta: objectMeta},
+			wantErr:        ErrContainerNotFound,
+		},
+		{
+			name:           "target mismatch",
+			recommendation: newRecommendation("StatefulSet", analysisv1alpha1.AnalysisTypeReplicas, replicasValue),
+			target:         &appsv1.Deployment{ObjectMeta: objectMeta},
+			wantErr:        ErrTargetMismatch,
+		},
+		{
+			name:           "custom workload replicas",
+			recommendation: newRecommendation("CloneSet", analysisv1alpha1.AnalysisTypeReplicas, replicasValue),
+			target:         custom,
+			wantType:       types.MergePatchType,
+			wantData:       `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:           "custom workload resource",
+			recommendation: newRecommendation("CloneSet", analysisv1alpha1.AnalysisTypeResource, resourceValue),
+			target:         custom,
+			wantType:       types.JSONPatchType,
+			wantData:       `[{"op":"add","path":"/spec/template/spec/containers/1/resources/requests/cpu","value":"500m"},{"op":"add","path":"/spec/template/spec/containers/1/resources/requests/memory","value":"256Mi"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := Build(tt.recommendation, tt.target)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Build() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() failed: %v", err)
+			}
+			if patch.Type != tt.wantType {
+				t.Errorf("Build() type = %s, want %s", patch.Type, tt.wantType)
+			}
+			if string(patch.Data) != tt.wantData {
+				t.Errorf("Build() data = %s, want %s", patch.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestBuildFromNilProposal(t *testing.T) {
+	target := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}
+	if _, err := BuildFromProposal(corev1.ObjectReference{Kind: "Deployment"}, nil, target); !errors.Is(err, ErrNothingToPatch) {
+		t.Errorf("BuildFromProposal() error = %v, want %v", err, ErrNothingToPatch)
+	}
+}
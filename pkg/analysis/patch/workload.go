@@ -0,0 +1,120 @@
+package patch
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// kindInfo describes where a built-in workload kind keeps its replicas and pod template.
+type kindInfo struct {
+	group        string
+	scalable     bool
+	templatePath []string
+}
+
+var podTemplatePath = []string{"spec", "template"}
+
+var builtinKinds = map[string]kindInfo{
+	"Deployment":  {group: appsv1.GroupName, scalable: true, templatePath: podTemplatePath},
+	"StatefulSet": {group: appsv1.GroupName, scalable: true, templatePath: podTemplatePath},
+	"ReplicaSet":  {group: appsv1.GroupName, scalable: true, templatePath: podTemplatePath},
+	"DaemonSet":   {group: appsv1.GroupName, templatePath: podTemplatePath},
+	"Job":         {group: batchv1.GroupName, templatePath: podTemplatePath},
+	"CronJob":     {group: batchv1.GroupName, templatePath: []string{"spec", "jobTemplate", "spec", "template"}},
+}
+
+// workload is the kind-independent view of a patch target.
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+	// builtin workloads accept strategic merge patches, custom resources do not.
+	builtin bool
+	kindInfo
+	object map[string]interface{}
+}
+
+func newWorkload(target runtime.Object) (*workload, error) {
+	var kind string
+	switch t := target.(type) {
+	case *appsv1.Deployment:
+		kind = "Deployment"
+	case *appsv1.StatefulSet:
+		kind = "StatefulSet"
+	case *appsv1.ReplicaSet:
+		kind = "ReplicaSet"
+	case *appsv1.DaemonSet:
+		kind = "DaemonSet"
+	case *batchv1.Job:
+		kind = "Job"
+	case *batchv1.CronJob, *batchv1beta1.CronJob:
+		kind = "CronJob"
+	case *unstructured.Unstructured:
+		return newUnstructuredWorkload(t)
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedTarget, target)
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(target)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: object}
+	return &workload{
+		kind:      kind,
+		namespace: u.GetNamespace(),
+		name:      u.GetName(),
+		builtin:   true,
+		kindInfo:  builtinKinds[kind],
+		object:    object,
+	}, nil
+}
+
+func newUnstructuredWorkload(u *unstructured.Unstructured) (*workload, error) {
+	w := &workload{
+		kind:      u.GetKind(),
+		namespace: u.GetNamespace(),
+		name:      u.GetName(),
+		object:    u.Object,
+	}
+
+	gv, err := schema.ParseGroupVersion(u.GetAPIVersion())
+	if err != nil {
+		return nil, err
+	}
+	if info, ok := builtinKinds[w.kind]; ok && info.group == gv.Group {
+		w.builtin = true
+		w.kindInfo = info
+		return w, nil
+	}
+
+	// Custom workloads are expected to follow the layout of a Deployment.
+	_, w.scalable, _ = unstructured.NestedFieldNoCopy(u.Object, "spec", "replicas")
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, append(podTemplatePath, "spec", "containers")...); found {
+		w.templatePath = podTemplatePath
+	}
+	return w, nil
+}
+
+// containers returns the containers of the pod template of the workload.
+func (w *workload) containers() ([]interface{}, error) {
+	if w.templatePath == nil {
+		return nil, fmt.Errorf("%w: %s %s has no pod template", ErrUnsupportedTarget, w.kind, w.name)
+	}
+	// Converted typed objects keep empty lists as nil, so a missing list is not an error.
+	field, _, err := unstructured.NestedFieldNoCopy(w.object, append(w.templatePath, "spec", "containers")...)
+	if err != nil || field == nil {
+		return nil, err
+	}
+	containers, ok := field.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s has malformed containers", ErrUnsupportedTarget, w.kind, w.name)
+	}
+	return containers, nil
+}
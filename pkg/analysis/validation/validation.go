@@ -0,0 +1,160 @@
+// Package validation validates the objects of the analysis API group.
+package validation
+
+import (
+	"fmt"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	analysisapi "github.com/gocrane/api/analysis/v1alpha1"
+	utilvalidation "github.com/gocrane/api/pkg/util/validation"
+)
+
+var (
+	supportedAnalysisTypes = sets.NewString(
+		string(analysisapi.AnalysisTypeReplicas),
+		string(analysisapi.AnalysisTypeResource),
+	)
+	supportedAdoptionTypes = sets.NewString(
+		string(analysisapi.AdoptionTypeStatus),
+		string(analysisapi.AdoptionTypeStatusAndAnnotation),
+		string(analysisapi.AdoptionTypeAuto),
+	)
+	supportedCompletionStrategyTypes = sets.NewString(
+		string(analysisapi.CompletionStrategyOnce),
+		string(analysisapi.CompletionStrategyPeriodical),
+	)
+)
+
+// ValidateRecommendation validates a Recommendation.
+func ValidateRecommendation(recommendation *analysisapi.Recommendation) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	if recommendation.Spec.TargetRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "kind"), ""))
+	}
+	if recommendation.Spec.TargetRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "name"), ""))
+	}
+	allErrs = append(allErrs, validateAnalysisType(recommendation.Spec.Type, fldPath.Child("type"))...)
+	allErrs = append(allErrs, ValidateCompletionStrategy(&recommendation.Spec.CompletionStrategy, fldPath.Child("completionStrategy"))...)
+	if recommendation.Spec.AdoptionType != "" && !supportedAdoptionTypes.Has(string(recommendation.Spec.AdoptionType)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("adoptionType"), recommendation.Spec.AdoptionType, supportedAdoptionTypes.List()))
+	}
+
+	return allErrs
+}
+
+// ValidateAnalytics validates an Analytics.
+func ValidateAnalytics(analytics *analysisapi.Analytics) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateAnalysisType(analytics.Spec.Type, fldPath.Child("type"))...)
+	allErrs = append(allErrs, validateResourceSelectors(analytics.Spec.ResourceSelectors, fldPath.Child("resourceSelectors"))...)
+	allErrs = append(allErrs, ValidateCompletionStrategy(&analytics.Spec.CompletionStrategy, fldPath.Child("completionStrategy"))...)
+
+	return allErrs
+}
+
+// ValidateCompletionStrategy validates a CompletionStrategy. A periodical strategy requires a positive period.
+func ValidateCompletionStrategy(strategy *analysisapi.CompletionStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if strategy.CompletionStrategyType != "" && !supportedCompletionStrategyTypes.Has(string(strategy.CompletionStrategyType)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("completionStrategyType"), strategy.CompletionStrategyType, supportedCompletionStrategyTypes.List()))
+	}
+	if strategy.PeriodSeconds != nil && *strategy.PeriodSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("periodSeconds"), *strategy.PeriodSeconds, "must be greater than 0"))
+	}
+	if strategy.CompletionStrategyType == analysisapi.CompletionStrategyPeriodical && strategy.PeriodSeconds == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("periodSeconds"), fmt.Sprintf("must be set when completionStrategyType is %s", strategy.CompletionStrategyType)))
+	}
+
+	return allErrs
+}
+
+// ValidateRecommendationRule validates a RecommendationRule.
+func ValidateRecommendationRule(rule *analysisapi.RecommendationRule) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateResourceSelectors(rule.Spec.ResourceSelectors, fldPath.Child("resourceSelectors"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(rule.Spec.RunInterval, fldPath.Child("runInterval"))...)
+
+	if len(rule.Spec.Recommenders) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("recommenders"), ""))
+	}
+	recommenders := sets.NewString(analysisapi.AllRecommenderType...)
+	names := sets.NewString()
+	for i, recommender := range rule.Spec.Recommenders {
+		idxPath := fldPath.Child("recommenders").Index(i)
+		if !recommenders.Has(recommender.Name) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("name"), recommender.Name, recommenders.List()))
+		} else if names.Has(recommender.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), recommender.Name))
+		}
+		names.Insert(recommender.Name)
+	}
+
+	return allErrs
+}
+
+// ValidateConfigSet validates a ConfigSet. At most one Config may omit targets and act as the global default.
+func ValidateConfigSet(configSet *analysisapi.ConfigSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("configs")
+	defaults := 0
+	for i, config := range configSet.Configs {
+		idxPath := fldPath.Index(i)
+		if len(config.Targets) == 0 {
+			defaults++
+			if defaults > 1 {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("targets"), "global default"))
+			}
+		}
+		for j, target := range config.Targets {
+			if target.Name != "" && target.Kind == "" {
+				allErrs = append(allErrs, field.Required(idxPath.Child("targets").Index(j).Child("kind"), "must be set when name is set"))
+			}
+		}
+		if len(config.Properties) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("properties"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAnalysisType(analysisType analysisapi.AnalysisType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if analysisType == "" {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+	} else if !supportedAnalysisTypes.Has(string(analysisType)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, analysisType, supportedAnalysisTypes.List()))
+	}
+	return allErrs
+}
+
+func validateResourceSelectors(selectors []analysisapi.ResourceSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(selectors) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+	}
+	for i, selector := range selectors {
+		idxPath := fldPath.Index(i)
+		if selector.Kind == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("kind"), ""))
+		}
+		if selector.LabelSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(selector.LabelSelector, idxPath.Child("labelSelector"))...)
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,182 @@
+// Package cron evaluates the CronSpecs of an EffectiveHorizontalPodAutoscaler.
+//
+// A cron is active from a trigger of its Start schedule, inclusive, until the
+// following trigger of its End schedule, exclusive. Both schedules are standard
+// five-field crontab expressions evaluated in the TimeZone of the cron, UTC by
+// default, so daylight saving time changes of the time zone are honoured.
+//
+// When several crons are active at the same time, the effective target replicas
+// are the maximum TargetReplicas among them.
+package cron
+
+import (
+	"fmt"
+	"time"
+
+	robfigcron "github.com/robfig/cron/v3"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+)
+
+// ParseStandard parses a standard five-field crontab expression, also accepting descriptors such as @daily.
+func ParseStandard(schedule string) (robfigcron.Schedule, error) {
+	return robfigcron.ParseStandard(schedule)
+}
+
+// LoadLocation returns the location of a CronSpec TimeZone. A nil time zone is UTC.
+func LoadLocation(timeZone *string) (*time.Location, error) {
+	if timeZone == nil || *timeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(*timeZone)
+}
+
+// Schedule is a parsed CronSpec.
+type Schedule struct {
+	Spec     autoscalingapi.CronSpec
+	start    robfigcron.Schedule
+	end      robfigcron.Schedule
+	location *time.Location
+}
+
+// NewSchedule parses spec.
+func NewSchedule(spec autoscalingapi.CronSpec) (*Schedule, error) {
+	location, err := LoadLocation(spec.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("cron %s: invalid time zone: %v", spec.Name, err)
+	}
+	start, err := ParseStandard(spec.Start)
+	if err != nil {
+		return nil, fmt.Errorf("cron %s: invalid start %q: %v", spec.Name, spec.Start, err)
+	}
+	end, err := ParseStandard(spec.End)
+	if err != nil {
+		return nil, fmt.Errorf("cron %s: invalid end %q: %v", spec.Name, spec.End, err)
+	}
+	return &Schedule{Spec: spec, start: start, end: end, location: location}, nil
+}
+
+// IsActive reports whether the cron is active at t, that is whether its last
+// start at or before t is not followed by an end at or before t. Starts and ends
+// need not alternate: a start inside an open window does not extend it, and an end
+// outside a window does nothing.
+func (s *Schedule) IsActive(t time.Time) bool {
+	local := t.In(s.location)
+	lastStart := previous(s.start, local)
+	if lastStart.IsZero() {
+		return false
+	}
+	// A start that coincides with an end does not open a window.
+	end := s.end.Next(lastStart.Add(-time.Second))
+	return end.IsZero() || end.After(local)
+}
+
+// NextTransition returns the first time after t at which the cron becomes
+// active or inactive. It is the zero time if the cron never changes state again.
+func (s *Schedule) NextTransition(t time.Time) time.Time {
+	local := t.In(s.location)
+	if s.IsActive(t) {
+		return s.end.Next(local)
+	}
+	for next := s.start.Next(local); !next.IsZero() && next.Sub(local) <= maxSearch; next = s.start.Next(next) {
+		if s.IsActive(next) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// maxSearch bounds the searches of the schedules. A search costs a call of Next per
+// trigger in the searched range, so finding the start of the next window may cost as
+// many calls as the schedule of starts triggers in maxSearch when all of them coincide
+// with ends, about half a million for a schedule triggering every minute.
+const maxSearch = 366 * 24 * time.Hour
+
+// previous returns the last trigger of schedule at or before t, the zero time if there is
+// none within maxSearch. It looks back over doubling ranges, so that it costs a few calls of
+// Next for frequent and sparse schedules alike.
+func previous(schedule robfigcron.Schedule, t time.Time) time.Time {
+	for lookback := time.Minute; ; lookback *= 2 {
+		if lookback > maxSearch {
+			lookback = maxSearch
+		}
+		var last time.Time
+		for next := schedule.Next(t.Add(-lookback)); !next.IsZero() && !next.After(t); next = schedule.Next(next) {
+			last = next
+		}
+		if !last.IsZero() || lookback == maxSearch {
+			return last
+		}
+	}
+}
+
+// Evaluator evaluates a set of crons.
+type Evaluator struct {
+	schedules []*Schedule
+}
+
+// NewEvaluator parses crons.
+func NewEvaluator(crons []autoscalingapi.CronSpec) (*Evaluator, error) {
+	e := &Evaluator{}
+	for _, spec := range crons {
+		s, err := NewSchedule(spec)
+		if err != nil {
+			return nil, err
+		}
+		e.schedules = append(e.schedules, s)
+	}
+	return e, nil
+}
+
+// Result is the evaluation of a set of crons at a point in time.
+type Result struct {
+	// Time is the evaluated time.
+	Time time.Time
+	// Active are the crons active at Time, in their declaration order.
+	Active []autoscalingapi.CronSpec
+	// TargetReplicas is the maximum TargetReplicas of the active crons, nil when no cron is active.
+	TargetReplicas *int32
+	// NextTransition is the first time after Time at which a cron becomes active or inactive,
+	// the zero time if none ever does.
+	NextTransition time.Time
+}
+
+// Evaluate evaluates the crons at t.
+func (e *Evaluator) Evaluate(t time.Time) Result {
+	result := Result{Time: t}
+	for _, s := range e.schedules {
+		if s.IsActive(t) {
+			result.Active = append(result.Active, s.Spec)
+			if result.TargetReplicas == nil || s.Spec.TargetReplicas > *result.TargetReplicas {
+				replicas := s.Spec.TargetReplicas
+				result.TargetReplicas = &replicas
+			}
+		}
+		if next := s.NextTransition(t); !next.IsZero() && (result.NextTransition.IsZero() || next.Before(result.NextTransition)) {
+			result.NextTransition = next
+		}
+	}
+	return result
+}
+
+// Timeline returns the evaluation at from followed by the evaluation at every
+// transition until, and including, until.
+func (e *Evaluator) Timeline(from, until time.Time) []Result {
+	results := []Result{e.Evaluate(from)}
+	for {
+		next := results[len(results)-1].NextTransition
+		if next.IsZero() || next.After(until) {
+			return results
+		}
+		results = append(results, e.Evaluate(next))
+	}
+}
+
+// Evaluate evaluates crons at t.
+func Evaluate(crons []autoscalingapi.CronSpec, t time.Time) (Result, error) {
+	e, err := NewEvaluator(crons)
+	if err != nil {
+		return Result{}, err
+	}
+	return e.Evaluate(t), nil
+}
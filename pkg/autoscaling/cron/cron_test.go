@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+)
+
+func TestEvaluate(t *testing.T) {
+	newYork := "America/New_York"
+	crons := []autoscalingapi.CronSpec{
+		{Name: "night", TimeZone: &newYork, Start: "0 22 * * *", End: "0 6 * * *", TargetReplicas: 2},
+		{Name: "batch", Start: "0 3 * * *", End: "0 5 * * *", TargetReplicas: 5},
+	}
+
+	tests := []struct {
+		name               string
+		time               string
+		wantActive         []string
+		wantTargetReplicas int32
+		wantNextTransition string
+	}{
+		{
+			name:               "before any window",
+			time:               "2026-03-07T20:00:00Z",
+			wantNextTransition: "2026-03-08T03:00:00Z",
+		},
+		{
+			name:               "start is inclusive",
+			time:               "2026-03-08T03:00:00Z",
+			wantActive:         []string{"night", "batch"},
+			wantTargetReplicas: 5,
+			wantNextTransition: "2026-03-08T05:00:00Z",
+		},
+		{
+			name:               "max target replicas wins",
+			time:               "2026-03-08T04:00:00Z",
+			wantActive:         []string{"night", "batch"},
+			wantTargetReplicas: 5,
+			wantNextTransition: "2026-03-08T05:00:00Z",
+		},
+		{
+			name:               "end is exclusive",
+			time:               "2026-03-08T05:00:00Z",
+			wantActive:         []string{"night"},
+			wantTargetReplicas: 2,
+			// 06:00 EDT, daylight saving time started at 02:00 local time.
+			wantNextTransition: "2026-03-08T10:00:00Z",
+		},
+		{
+			name:               "window ends in daylight saving time",
+			time:               "2026-03-08T10:30:00Z",
+			wantNextTransition: "2026-03-09T02:00:00Z",
+		},
+	}
+
+	e, err := NewEvaluator(crons)
+	if err != nil {
+		t.Fatalf("NewEvaluator() failed: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.time)
+			result := e.Evaluate(now)
+
+			var active []string
+			for _, c := range result.Active {
+				active = append(active, c.Name)
+			}
+			if len(active) != len(tt.wantActive) {
+				t.Fatalf("active = %v, want %v", active, tt.wantActive)
+			}
+			for i := range active {
+				if active[i] != tt.wantActive[i] {
+					t.Fatalf("active = %v, want %v", active, tt.wantActive)
+				}
+			}
+			if tt.wantActive == nil && result.TargetReplicas != nil {
+				t.Errorf("target replicas = %d, want nil", *result.TargetReplicas)
+			}
+			if tt.wantActive != nil && (result.TargetReplicas == nil || *result.TargetReplicas != tt.wantTargetReplicas) {
+				t.Errorf("target replicas = %v, want %d", result.TargetReplicas, tt.wantTargetReplicas)
+			}
+			wantNext, _ := time.Parse(time.RFC3339, tt.wantNextTransition)
+			if !result.NextTransition.Equal(wantNext) {
+				t.Errorf("next transition = %s, want %s", result.NextTransition.UTC().Format(time.RFC3339), tt.wantNextTransition)
+			}
+		})
+	}
+}
+
+func TestScheduleNotAlternating(t *testing.T) {
+	// Starts on Mondays only, ends every day: the window is open on Mondays from 9:00 to 18:00.
+	s, err := NewSchedule(autoscalingapi.CronSpec{Name: "monday", Start: "0 9 * * 1", End: "0 18 * * *"})
+	if err != nil {
+		t.Fatalf("NewSchedule() failed: %v", err)
+	}
+
+	tests := []struct {
+		time               string
+		wantActive         bool
+		wantNextTransition string
+	}{
+		{time: "2026-03-02T08:00:00Z", wantNextTransition: "2026-03-02T09:00:00Z"},
+		{time: "2026-03-02T10:00:00Z", wantActive: true, wantNextTransition: "2026-03-02T18:00:00Z"},
+		{time: "2026-03-02T18:00:00Z", wantNextTransition: "2026-03-09T09:00:00Z"},
+		{time: "2026-03-03T10:00:00Z", wantNextTransition: "2026-03-09T09:00:00Z"},
+		{time: "2026-03-08T17:00:00Z", wantNextTransition: "2026-03-09T09:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.time, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.time)
+			if active := s.IsActive(now); active != tt.wantActive {
+				t.Errorf("active = %v, want %v", active, tt.wantActive)
+			}
+			wantNext, _ := time.Parse(time.RFC3339, tt.wantNextTransition)
+			if next := s.NextTransition(now); !next.Equal(wantNext) {
+				t.Errorf("next transition = %s, want %s", next.UTC().Format(time.RFC3339), tt.wantNextTransition)
+			}
+		})
+	}
+}
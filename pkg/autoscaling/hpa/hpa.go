@@ -0,0 +1,307 @@
+// Package hpa builds the HorizontalPodAutoscaler that realizes an EffectiveHorizontalPodAutoscaler.
+//
+// The builder is deterministic: the same EffectiveHorizontalPodAutoscaler and
+// Options always produce the same HorizontalPodAutoscaler, so controllers and
+// offline diffing tools agree on the desired object.
+package hpa
+
+import (
+	"fmt"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+)
+
+const (
+	// namePrefix prefixes the names of the objects generated for an EffectiveHorizontalPodAutoscaler.
+	namePrefix = "ehpa-"
+
+	// CronMetricName is the name of the external metric that drives cron scaling.
+	// Its value is the target replicas of the active crons, and its target average value is 1,
+	// so the HorizontalPodAutoscaler recommends exactly the cron target replicas.
+	CronMetricName = "crane_autoscaling_cron"
+
+	// defaultCPUUtilization is the target of the default metric used when no metric is set.
+	defaultCPUUtilization = int32(80)
+)
+
+// Options are the inputs of the builder that are not part of the EffectiveHorizontalPodAutoscaler.
+type Options struct {
+	// PodRequests are the resource requests of a single pod of the scale target.
+	// They turn the utilization target of a predicted resource metric into the
+	// average value target of its external prediction metric.
+	PodRequests corev1.ResourceList
+}
+
+// Name returns the name of the HorizontalPodAutoscaler of ehpa.
+func Name(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) string {
+	return namePrefix + ehpa.Name
+}
+
+// SubstituteName returns the name of the Substitute scaled in place of the target of ehpa in preview mode.
+func SubstituteName(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) string {
+	return namePrefix + ehpa.Name
+}
+
+// Labels returns the labels of the objects generated for ehpa.
+func Labels(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) map[string]string {
+	return map[string]string{
+		autoscalingapi.LabelEffectiveHPAName: ehpa.Name,
+	}
+}
+
+// OwnerReference returns the controller reference to ehpa set on the objects generated for it.
+func OwnerReference(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) metav1.OwnerReference {
+	return *metav1.NewControllerRef(ehpa, autoscalingapi.SchemeGroupVersion.WithKind("EffectiveHorizontalPodAutoscaler"))
+}
+
+// ScaleTargetRef returns the scale target of the HorizontalPodAutoscaler of ehpa:
+// the Substitute of ehpa in preview mode, the target of ehpa otherwise.
+func ScaleTargetRef(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) autoscalingv2.CrossVersionObjectReference {
+	if ehpa.Spec.ScaleStrategy == autoscalingapi.ScaleStrategyPreview {
+		return autoscalingv2.CrossVersionObjectReference{
+			APIVersion: autoscalingapi.SchemeGroupVersion.String(),
+			Kind:       "Substitute",
+			Name:       SubstituteName(ehpa),
+		}
+	}
+	return ehpa.Spec.ScaleTargetRef
+}
+
+// NewSubstitute returns the Substitute scaled by the HorizontalPodAutoscaler of ehpa in preview mode.
+func NewSubstitute(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) *autoscalingapi.Substitute {
+	return &autoscalingapi.Substitute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingapi.SchemeGroupVersion.String(),
+			Kind:       "Substitute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ehpa.Namespace,
+			Name:            SubstituteName(ehpa),
+			Labels:          Labels(ehpa),
+			OwnerReferences: []metav1.OwnerReference{OwnerReference(ehpa)},
+		},
+		Spec: autoscalingapi.SubstituteSpec{
+			SubstituteTargetRef: ehpa.Spec.ScaleTargetRef,
+		},
+	}
+}
+
+// Metrics returns the metrics of the HorizontalPodAutoscaler of ehpa: the metrics of ehpa,
+// or 80% average cpu utilization when it has none, followed by the external prediction
+// metric of every predicted metric when prediction is enabled, and by the cron metric when ehpa has crons.
+func Metrics(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler, opts Options) ([]autoscalingv2.MetricSpec, error) {
+	var metrics []autoscalingv2.MetricSpec
+	for i := range ehpa.Spec.Metrics {
+		metrics = append(metrics, *ehpa.Spec.Metrics[i].DeepCopy())
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, DefaultMetric())
+	}
+
+	if ehpa.Spec.Prediction != nil {
+		var predictionMetrics []autoscalingv2.MetricSpec
+		for i := range metrics {
+			metric, ok, err := PredictionMetric(ehpa, &metrics[i], opts)
+			if err != nil {
+				return nil, fmt.Errorf("metric %d: %w", i, err)
+			}
+			if ok {
+				predictionMetrics = append(predictionMetrics, metric)
+			}
+		}
+		metrics = append(metrics, predictionMetrics...)
+	}
+
+	if len(ehpa.Spec.Crons) > 0 {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name:     CronMetricName,
+					Selector: metricSelector(ehpa),
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(1, resource.DecimalSI),
+				},
+			},
+		})
+	}
+
+	return metrics, nil
+}
+
+// DefaultMetric returns the metric used when an EffectiveHorizontalPodAutoscaler sets none.
+func DefaultMetric() autoscalingv2.MetricSpec {
+	utilization := defaultCPUUtilization
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+// PredictionMetricName returns the name of the external metric that serves the prediction of metric.
+// Container resource metrics are not predicted, and false is returned for them.
+//
+//	Resource:  crane_pod_<resource>_usage, e.g. crane_pod_cpu_usage
+//	Pods:      crane_pods_<metric name>
+//	Object:    crane_object_<metric name>
+//	External:  crane_external_<metric name>
+func PredictionMetricName(metric *autoscalingv2.MetricSpec) (string, bool) {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource != nil {
+			return "crane_pod_" + strings.ToLower(string(metric.Resource.Name)) + "_usage", true
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods != nil {
+			return "crane_pods_" + metric.Pods.Metric.Name, true
+		}
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object != nil {
+			return "crane_object_" + metric.Object.Metric.Name, true
+		}
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External != nil {
+			return "crane_external_" + metric.External.Metric.Name, true
+		}
+	}
+	return "", false
+}
+
+// PredictionMetric returns the external metric serving the prediction of metric.
+// It returns false when metric is not predicted.
+func PredictionMetric(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler, metric *autoscalingv2.MetricSpec, opts Options) (autoscalingv2.MetricSpec, bool, error) {
+	name, ok := PredictionMetricName(metric)
+	if !ok {
+		return autoscalingv2.MetricSpec{}, false, nil
+	}
+
+	var target autoscalingv2.MetricTarget
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		var err error
+		if target, err = resourceAverageValueTarget(metric.Resource, opts); err != nil {
+			return autoscalingv2.MetricSpec{}, false, err
+		}
+	case autoscalingv2.PodsMetricSourceType:
+		target = *metric.Pods.Target.DeepCopy()
+	case autoscalingv2.ObjectMetricSourceType:
+		target = *metric.Object.Target.DeepCopy()
+	case autoscalingv2.ExternalMetricSourceType:
+		target = *metric.External.Target.DeepCopy()
+	}
+
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     name,
+				Selector: metricSelector(ehpa),
+			},
+			Target: target,
+		},
+	}, true, nil
+}
+
+// resourceAverageValueTarget returns the average value target of a predicted resource metric.
+// External metrics have no utilization target, so it is derived from the pod requests.
+func resourceAverageValueTarget(source *autoscalingv2.ResourceMetricSource, opts Options) (autoscalingv2.MetricTarget, error) {
+	switch source.Target.Type {
+	case autoscalingv2.AverageValueMetricType:
+		return *source.Target.DeepCopy(), nil
+	case autoscalingv2.UtilizationMetricType:
+		request, ok := opts.PodRequests[source.Name]
+		if !ok || request.IsZero() {
+			return autoscalingv2.MetricTarget{}, fmt.Errorf("pod requests of %s are required to predict its utilization", source.Name)
+		}
+		if source.Target.AverageUtilization == nil {
+			return autoscalingv2.MetricTarget{}, fmt.Errorf("utilization target of %s has no averageUtilization", source.Name)
+		}
+		averageValue := resource.NewMilliQuantity(request.MilliValue()*int64(*source.Target.AverageUtilization)/100, request.Format)
+		return autoscalingv2.MetricTarget{
+			Type:         autoscalingv2.AverageValueMetricType,
+			AverageValue: averageValue,
+		}, nil
+	default:
+		return autoscalingv2.MetricTarget{}, fmt.Errorf("unsupported target type %q of %s", source.Target.Type, source.Name)
+	}
+}
+
+func metricSelector(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			autoscalingapi.LabelEffectiveHPAUID: string(ehpa.UID),
+		},
+	}
+}
+
+// BuildV2beta2 returns the autoscaling/v2beta2 HorizontalPodAutoscaler of ehpa.
+func BuildV2beta2(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler, opts Options) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	metrics, err := Metrics(ehpa, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	minReplicas := int32(1)
+	if ehpa.Spec.MinReplicas != nil {
+		minReplicas = *ehpa.Spec.MinReplicas
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+			Kind:       "HorizontalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ehpa.Namespace,
+			Name:            Name(ehpa),
+			Labels:          Labels(ehpa),
+			OwnerReferences: []metav1.OwnerReference{OwnerReference(ehpa)},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: ScaleTargetRef(ehpa),
+			MinReplicas:    &minReplicas,
+			MaxReplicas:    ehpa.Spec.MaxReplicas,
+			Metrics:        metrics,
+			Behavior:       ehpa.Spec.Behavior.DeepCopy(),
+		},
+	}, nil
+}
+
+// V2APIVersion is the apiVersion of the autoscaling/v2 HorizontalPodAutoscaler.
+const V2APIVersion = "autoscaling/v2"
+
+// BuildV2 returns the autoscaling/v2 HorizontalPodAutoscaler of ehpa.
+// The spec of autoscaling/v2 is field for field the spec of autoscaling/v2beta2,
+// and the typed autoscaling/v2 API is not vendored by this module, so the object is unstructured.
+func BuildV2(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler, opts Options) (*unstructured.Unstructured, error) {
+	hpa, err := BuildV2beta2(ehpa, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hpa)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: object}
+	u.SetAPIVersion(V2APIVersion)
+	// Drop the empty status of the typed object.
+	unstructured.RemoveNestedField(u.Object, "status")
+	return u, nil
+}
@@ -0,0 +1,138 @@
+package hpa
+
+import (
+	"reflect"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+)
+
+func newEHPA() *autoscalingapi.EffectiveHorizontalPodAutoscaler {
+	return &autoscalingapi.EffectiveHorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "uid-1"},
+		Spec: autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			MaxReplicas:    10,
+		},
+	}
+}
+
+func TestBuildV2beta2Defaults(t *testing.T) {
+	hpa, err := BuildV2beta2(newEHPA(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hpa.Name != "ehpa-web" || hpa.Namespace != "default" {
+		t.Errorf("unexpected name %s/%s", hpa.Namespace, hpa.Name)
+	}
+	if hpa.Labels[autoscalingapi.LabelEffectiveHPAName] != "web" {
+		t.Errorf("unexpected labels %v", hpa.Labels)
+	}
+	if len(hpa.OwnerReferences) != 1 || hpa.OwnerReferences[0].UID != "uid-1" || !*hpa.OwnerReferences[0].Controller {
+		t.Errorf("unexpected owner references %v", hpa.OwnerReferences)
+	}
+	if *hpa.Spec.MinReplicas != 1 || hpa.Spec.MaxReplicas != 10 {
+		t.Errorf("unexpected replicas %d-%d", *hpa.Spec.MinReplicas, hpa.Spec.MaxReplicas)
+	}
+	if want := []autoscalingv2.MetricSpec{DefaultMetric()}; !reflect.DeepEqual(hpa.Spec.Metrics, want) {
+		t.Errorf("got metrics %v, want %v", hpa.Spec.Metrics, want)
+	}
+}
+
+func TestBuildV2beta2Preview(t *testing.T) {
+	ehpa := newEHPA()
+	ehpa.Spec.ScaleStrategy = autoscalingapi.ScaleStrategyPreview
+
+	hpa, err := BuildV2beta2(ehpa, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := autoscalingv2.CrossVersionObjectReference{APIVersion: "autoscaling.crane.io/v1alpha1", Kind: "Substitute", Name: "ehpa-web"}
+	if hpa.Spec.ScaleTargetRef != want {
+		t.Errorf("got scale target %v, want %v", hpa.Spec.ScaleTargetRef, want)
+	}
+	if substitute := NewSubstitute(ehpa); substitute.Name != want.Name || substitute.Spec.SubstituteTargetRef != ehpa.Spec.ScaleTargetRef {
+		t.Errorf("unexpected substitute %v", substitute)
+	}
+}
+
+func TestBuildV2beta2PredictionAndCron(t *testing.T) {
+	utilization := int32(50)
+	ehpa := newEHPA()
+	ehpa.Spec.Metrics = []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization},
+			},
+		},
+		{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "http_requests"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(100, resource.DecimalSI)},
+			},
+		},
+	}
+	ehpa.Spec.Prediction = &autoscalingapi.Prediction{}
+	ehpa.Spec.Crons = []autoscalingapi.CronSpec{{Name: "day", Start: "0 8 * * *", End: "0 20 * * *", TargetReplicas: 5}}
+
+	if _, err := BuildV2beta2(ehpa, Options{}); err == nil {
+		t.Fatal("expected an error without pod requests")
+	}
+
+	opts := Options{PodRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}}
+	hpa, err := BuildV2beta2(ehpa, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, metric := range hpa.Spec.Metrics[2:] {
+		if metric.Type != autoscalingv2.ExternalMetricSourceType {
+			t.Fatalf("unexpected metric type %s", metric.Type)
+		}
+		if metric.External.Metric.Selector.MatchLabels[autoscalingapi.LabelEffectiveHPAUID] != "uid-1" {
+			t.Errorf("unexpected selector %v", metric.External.Metric.Selector)
+		}
+		names = append(names, metric.External.Metric.Name)
+	}
+	if want := []string{"crane_pod_cpu_usage", "crane_pods_http_requests", CronMetricName}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got metric names %v, want %v", names, want)
+	}
+	if got := hpa.Spec.Metrics[2].External.Target.AverageValue; got.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("got cpu prediction target %s, want 1", got.String())
+	}
+
+	again, err := BuildV2beta2(ehpa, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hpa, again) {
+		t.Error("builder is not deterministic")
+	}
+}
+
+func TestBuildV2(t *testing.T) {
+	u, err := BuildV2(newEHPA(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.GetAPIVersion() != V2APIVersion || u.GetKind() != "HorizontalPodAutoscaler" || u.GetName() != "ehpa-web" {
+		t.Errorf("unexpected object %s %s %s", u.GetAPIVersion(), u.GetKind(), u.GetName())
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status"); found {
+		t.Error("status should be dropped")
+	}
+	maxReplicas, _, _ := unstructured.NestedInt64(u.Object, "spec", "maxReplicas")
+	if maxReplicas != 10 {
+		t.Errorf("got maxReplicas %d, want 10", maxReplicas)
+	}
+}
@@ -0,0 +1,211 @@
+// Package prediction builds the TimeSeriesPrediction that predicts the metrics of an EffectiveHorizontalPodAutoscaler.
+//
+// Every predicted metric is identified by the name of the external metric that the
+// HorizontalPodAutoscaler built by package hpa queries, so the prediction of a metric
+// and its consumer agree on a single identifier.
+package prediction
+
+import (
+	"errors"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	"github.com/gocrane/api/pkg/autoscaling/hpa"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// defaultPredictionWindowSeconds is the prediction window used when the EffectiveHorizontalPodAutoscaler sets none.
+const defaultPredictionWindowSeconds = int32(3600)
+
+var (
+	// ErrPredictionDisabled is returned for an EffectiveHorizontalPodAutoscaler without prediction.
+	ErrPredictionDisabled = errors.New("prediction is not enabled")
+	// ErrNoAlgorithm is returned for an EffectiveHorizontalPodAutoscaler without prediction algorithm.
+	ErrNoAlgorithm = errors.New("prediction algorithm is not set")
+	// ErrUnsupportedSelector is returned for a metric selector that can not be expressed as query conditions.
+	ErrUnsupportedSelector = errors.New("unsupported metric selector")
+)
+
+// Name returns the name of the TimeSeriesPrediction of ehpa.
+func Name(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) string {
+	return hpa.Name(ehpa)
+}
+
+// Build returns the TimeSeriesPrediction of ehpa.
+func Build(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) (*predictionapi.TimeSeriesPrediction, error) {
+	prediction := ehpa.Spec.Prediction
+	if prediction == nil {
+		return nil, ErrPredictionDisabled
+	}
+	if prediction.PredictionAlgorithm == nil {
+		return nil, ErrNoAlgorithm
+	}
+
+	metrics, err := PredictionMetrics(ehpa)
+	if err != nil {
+		return nil, err
+	}
+
+	windowSeconds := defaultPredictionWindowSeconds
+	if prediction.PredictionWindowSeconds != nil {
+		windowSeconds = *prediction.PredictionWindowSeconds
+	}
+
+	return &predictionapi.TimeSeriesPrediction{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: predictionapi.SchemeGroupVersion.String(),
+			Kind:       "TimeSeriesPrediction",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ehpa.Namespace,
+			Name:            Name(ehpa),
+			Labels:          hpa.Labels(ehpa),
+			OwnerReferences: []metav1.OwnerReference{hpa.OwnerReference(ehpa)},
+		},
+		Spec: predictionapi.TimeSeriesPredictionSpec{
+			PredictionMetrics: metrics,
+			TargetRef: corev1.ObjectReference{
+				APIVersion: ehpa.Spec.ScaleTargetRef.APIVersion,
+				Kind:       ehpa.Spec.ScaleTargetRef.Kind,
+				Namespace:  ehpa.Namespace,
+				Name:       ehpa.Spec.ScaleTargetRef.Name,
+			},
+			PredictionWindowSeconds: windowSeconds,
+		},
+	}, nil
+}
+
+// PredictionMetrics returns a PredictionMetric in instant mode for every predicted metric of ehpa, in the order of its metrics.
+// The default metric is predicted when ehpa sets none, and container resource metrics are not predicted.
+func PredictionMetrics(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) ([]predictionapi.PredictionMetric, error) {
+	metrics := ehpa.Spec.Metrics
+	if len(metrics) == 0 {
+		metrics = []autoscalingv2.MetricSpec{hpa.DefaultMetric()}
+	}
+
+	var algorithm predictionapi.Algorithm
+	if ehpa.Spec.Prediction != nil && ehpa.Spec.Prediction.PredictionAlgorithm != nil {
+		in := ehpa.Spec.Prediction.PredictionAlgorithm
+		algorithm = predictionapi.Algorithm{
+			AlgorithmType: in.AlgorithmType,
+			DSP:           in.DSP,
+			Percentile:    in.Percentile,
+			Linear:        in.Linear,
+			HoltWinters:   in.HoltWinters,
+		}
+	}
+
+	var predictionMetrics []predictionapi.PredictionMetric
+	for i := range metrics {
+		metric, ok, err := PredictionMetric(ehpa, &metrics[i])
+		if err != nil {
+			return nil, fmt.Errorf("metric %d: %w", i, err)
+		}
+		if !ok {
+			continue
+		}
+		metric.Algorithm = *algorithm.DeepCopy()
+		// The HorizontalPodAutoscaler consumes a single value per metric: the maximum over the prediction window.
+		metric.Mode = predictionapi.PredictionModeInstant
+		predictionMetrics = append(predictionMetrics, metric)
+	}
+	return predictionMetrics, nil
+}
+
+// PredictionMetric returns the PredictionMetric of metric without algorithm.
+// It returns false when metric is not predicted.
+//
+// Resource metrics are resource queries. Pods, object and external metrics are metric queries
+// whose conditions are their metric selector, unless ehpa overrides their query with an
+// AnnotationMetricQueryPrefix annotation, which makes them expression queries.
+func PredictionMetric(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler, metric *autoscalingv2.MetricSpec) (predictionapi.PredictionMetric, bool, error) {
+	identifier, ok := hpa.PredictionMetricName(metric)
+	if !ok {
+		return predictionapi.PredictionMetric{}, false, nil
+	}
+
+	var metricIdentifier autoscalingv2.MetricIdentifier
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		name := metric.Resource.Name
+		return predictionapi.PredictionMetric{
+			ResourceIdentifier: identifier,
+			Type:               predictionapi.ResourceQueryMetricType,
+			ResourceQuery:      &name,
+		}, true, nil
+	case autoscalingv2.PodsMetricSourceType:
+		metricIdentifier = metric.Pods.Metric
+	case autoscalingv2.ObjectMetricSourceType:
+		metricIdentifier = metric.Object.Metric
+	case autoscalingv2.ExternalMetricSourceType:
+		metricIdentifier = metric.External.Metric
+	}
+
+	if expression, ok := ehpa.Annotations[autoscalingapi.AnnotationMetricQueryPrefix+metricIdentifier.Name]; ok {
+		return predictionapi.PredictionMetric{
+			ResourceIdentifier: identifier,
+			Type:               predictionapi.ExpressionQueryMetricType,
+			ExpressionQuery:    &predictionapi.ExpressionQuery{Expression: expression},
+		}, true, nil
+	}
+
+	conditions, err := QueryConditions(metricIdentifier.Selector)
+	if err != nil {
+		return predictionapi.PredictionMetric{}, false, fmt.Errorf("%s: %w", metricIdentifier.Name, err)
+	}
+	return predictionapi.PredictionMetric{
+		ResourceIdentifier: identifier,
+		Type:               predictionapi.MetricQueryMetricType,
+		MetricQuery: &predictionapi.MetricQuery{
+			MetricName:      metricIdentifier.Name,
+			QueryConditions: conditions,
+		},
+	}, true, nil
+}
+
+// QueryConditions converts a metric selector to query conditions.
+// Match labels come first, sorted by key, followed by match expressions in their order.
+func QueryConditions(selector *metav1.LabelSelector) ([]predictionapi.QueryCondition, error) {
+	if selector == nil {
+		return nil, nil
+	}
+
+	var conditions []predictionapi.QueryCondition
+	for _, key := range sets.StringKeySet(selector.MatchLabels).List() {
+		conditions = append(conditions, predictionapi.QueryCondition{
+			Key:      key,
+			Operator: predictionapi.OperatorEqual,
+			Value:    []string{selector.MatchLabels[key]},
+		})
+	}
+
+	for _, expression := range selector.MatchExpressions {
+		condition := predictionapi.QueryCondition{Key: expression.Key}
+		switch expression.Operator {
+		case metav1.LabelSelectorOpIn:
+			condition.Operator = predictionapi.OperatorIn
+			condition.Value = append([]string(nil), expression.Values...)
+		case metav1.LabelSelectorOpNotIn:
+			if len(expression.Values) != 1 {
+				return nil, fmt.Errorf("%w: %s %s with %d values", ErrUnsupportedSelector, expression.Key, expression.Operator, len(expression.Values))
+			}
+			condition.Operator = predictionapi.OperatorNotEqual
+			condition.Value = []string{expression.Values[0]}
+		case metav1.LabelSelectorOpExists:
+			condition.Operator = predictionapi.OperatorRegexMatch
+			condition.Value = []string{".+"}
+		case metav1.LabelSelectorOpDoesNotExist:
+			condition.Operator = predictionapi.OperatorEqual
+			condition.Value = []string{""}
+		default:
+			return nil, fmt.Errorf("%w: %s %s", ErrUnsupportedSelector, expression.Key, expression.Operator)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
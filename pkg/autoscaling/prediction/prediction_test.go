@@ -0,0 +1,146 @@
+package prediction
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func newEHPA() *autoscalingapi.EffectiveHorizontalPodAutoscaler {
+	return &autoscalingapi.EffectiveHorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "web",
+			UID:         "uid-1",
+			Annotations: map[string]string{autoscalingapi.AnnotationMetricQueryPrefix + "qps": `sum(rate(http_requests_total{app="web"}[1m]))`},
+		},
+		Spec: autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+			MaxReplicas:    10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type:     autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceMemory},
+				},
+				{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{
+							Name: "http_requests",
+							Selector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"path": "/", "app": "web"},
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "code", Operator: metav1.LabelSelectorOpIn, Values: []string{"200", "204"}},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type:     autoscalingv2.ExternalMetricSourceType,
+					External: &autoscalingv2.ExternalMetricSource{Metric: autoscalingv2.MetricIdentifier{Name: "qps"}},
+				},
+				{
+					Type:              autoscalingv2.ContainerResourceMetricSourceType,
+					ContainerResource: &autoscalingv2.ContainerResourceMetricSource{Name: corev1.ResourceCPU, Container: "app"},
+				},
+			},
+			Prediction: &autoscalingapi.Prediction{
+				PredictionAlgorithm: &autoscalingapi.PredictionAlgorithm{
+					AlgorithmType: predictionapi.AlgorithmTypeDSP,
+					DSP:           &predictionapi.DSP{SampleInterval: "60s", HistoryLength: "7d"},
+				},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ehpa := newEHPA()
+	tsp, err := Build(ehpa)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tsp.Namespace != "default" || tsp.Name != "ehpa-web" {
+		t.Errorf("unexpected name %s/%s", tsp.Namespace, tsp.Name)
+	}
+	if len(tsp.OwnerReferences) != 1 || tsp.OwnerReferences[0].UID != "uid-1" {
+		t.Errorf("unexpected owner references %v", tsp.OwnerReferences)
+	}
+	if tsp.Spec.PredictionWindowSeconds != 3600 {
+		t.Errorf("got prediction window %d, want 3600", tsp.Spec.PredictionWindowSeconds)
+	}
+	wantTarget := corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "web"}
+	if tsp.Spec.TargetRef != wantTarget {
+		t.Errorf("got target %v, want %v", tsp.Spec.TargetRef, wantTarget)
+	}
+
+	memory := corev1.ResourceMemory
+	algorithm := predictionapi.Algorithm{
+		AlgorithmType: predictionapi.AlgorithmTypeDSP,
+		DSP:           &predictionapi.DSP{SampleInterval: "60s", HistoryLength: "7d"},
+	}
+	want := []predictionapi.PredictionMetric{
+		{
+			ResourceIdentifier: "crane_pod_memory_usage",
+			Type:               predictionapi.ResourceQueryMetricType,
+			ResourceQuery:      &memory,
+			Algorithm:          algorithm,
+			Mode:               predictionapi.PredictionModeInstant,
+		},
+		{
+			ResourceIdentifier: "crane_pods_http_requests",
+			Type:               predictionapi.MetricQueryMetricType,
+			MetricQuery: &predictionapi.MetricQuery{
+				MetricName: "http_requests",
+				QueryConditions: []predictionapi.QueryCondition{
+					{Key: "app", Operator: predictionapi.OperatorEqual, Value: []string{"web"}},
+					{Key: "path", Operator: predictionapi.OperatorEqual, Value: []string{"/"}},
+					{Key: "code", Operator: predictionapi.OperatorIn, Value: []string{"200", "204"}},
+				},
+			},
+			Algorithm: algorithm,
+			Mode:      predictionapi.PredictionModeInstant,
+		},
+		{
+			ResourceIdentifier: "crane_external_qps",
+			Type:               predictionapi.ExpressionQueryMetricType,
+			ExpressionQuery:    &predictionapi.ExpressionQuery{Expression: `sum(rate(http_requests_total{app="web"}[1m]))`},
+			Algorithm:          algorithm,
+			Mode:               predictionapi.PredictionModeInstant,
+		},
+	}
+	if !reflect.DeepEqual(tsp.Spec.PredictionMetrics, want) {
+		t.Errorf("got metrics %+v, want %+v", tsp.Spec.PredictionMetrics, want)
+	}
+	if tsp.Spec.PredictionMetrics[0].Algorithm.DSP == ehpa.Spec.Prediction.PredictionAlgorithm.DSP {
+		t.Error("algorithm should be copied")
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	ehpa := newEHPA()
+	ehpa.Spec.Prediction.PredictionAlgorithm = nil
+	if _, err := Build(ehpa); !errors.Is(err, ErrNoAlgorithm) {
+		t.Errorf("got %v, want %v", err, ErrNoAlgorithm)
+	}
+
+	ehpa.Spec.Prediction = nil
+	if _, err := Build(ehpa); !errors.Is(err, ErrPredictionDisabled) {
+		t.Errorf("got %v, want %v", err, ErrPredictionDisabled)
+	}
+
+	ehpa = newEHPA()
+	ehpa.Spec.Metrics[1].Pods.Metric.Selector.MatchExpressions[0].Operator = metav1.LabelSelectorOpNotIn
+	if _, err := Build(ehpa); !errors.Is(err, ErrUnsupportedSelector) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedSelector)
+	}
+}
@@ -0,0 +1,262 @@
+package simulation
+
+import (
+	"math"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// defaultScaleDownStabilizationWindowSeconds is the default scale down stabilization window of the HorizontalPodAutoscaler.
+const defaultScaleDownStabilizationWindowSeconds = int32(300)
+
+// defaultScaleUpRules returns the scale up rules used by the HorizontalPodAutoscaler when behavior does not set them.
+func defaultScaleUpRules() *autoscalingv2.HPAScalingRules {
+	selectPolicy := autoscalingv2.MaxPolicySelect
+	stabilizationWindowSeconds := int32(0)
+	return &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: &stabilizationWindowSeconds,
+		SelectPolicy:               &selectPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+}
+
+// defaultScaleDownRules returns the scale down rules used by the HorizontalPodAutoscaler when behavior does not set them.
+func defaultScaleDownRules() *autoscalingv2.HPAScalingRules {
+	selectPolicy := autoscalingv2.MaxPolicySelect
+	stabilizationWindowSeconds := defaultScaleDownStabilizationWindowSeconds
+	return &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: &stabilizationWindowSeconds,
+		SelectPolicy:               &selectPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+}
+
+// withDefaults fills the unset fields of rules with the fields of defaults.
+func withDefaults(rules, defaults *autoscalingv2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	if rules == nil {
+		return defaults
+	}
+	rules = rules.DeepCopy()
+	if rules.StabilizationWindowSeconds == nil {
+		rules.StabilizationWindowSeconds = defaults.StabilizationWindowSeconds
+	}
+	if rules.SelectPolicy == nil {
+		rules.SelectPolicy = defaults.SelectPolicy
+	}
+	if len(rules.Policies) == 0 {
+		rules.Policies = defaults.Policies
+	}
+	return rules
+}
+
+type recommendation struct {
+	time     time.Time
+	replicas int32
+}
+
+type scaleEvent struct {
+	time   time.Time
+	change int32
+}
+
+// autoscaler turns recommendations into replicas like the HorizontalPodAutoscaler controller does with behaviors.
+type autoscaler struct {
+	minReplicas, maxReplicas int32
+	scaleUp, scaleDown       *autoscalingv2.HPAScalingRules
+
+	replicas        int32
+	recommendations []recommendation
+	scaleUpEvents   []scaleEvent
+	scaleDownEvents []scaleEvent
+}
+
+func newAutoscaler(minReplicas, maxReplicas int32, behavior *autoscalingv2.HorizontalPodAutoscalerBehavior, replicas int32) *autoscaler {
+	a := &autoscaler{
+		minReplicas: minReplicas,
+		maxReplicas: maxReplicas,
+		scaleUp:     defaultScaleUpRules(),
+		scaleDown:   defaultScaleDownRules(),
+		replicas:    replicas,
+	}
+	if behavior != nil {
+		a.scaleUp = withDefaults(behavior.ScaleUp, a.scaleUp)
+		a.scaleDown = withDefaults(behavior.ScaleDown, a.scaleDown)
+	}
+	return a
+}
+
+// scale records recommended at t and returns, and applies, the replicas chosen for it.
+func (a *autoscaler) scale(t time.Time, recommended int32) int32 {
+	current := a.replicas
+	var desired int32
+	switch {
+	case current > a.maxReplicas:
+		desired = a.maxReplicas
+	case current < a.minReplicas:
+		desired = a.minReplicas
+	default:
+		desired = a.limit(t, a.stabilize(t, recommended))
+	}
+
+	if change := desired - current; change > 0 {
+		a.scaleUpEvents = append(a.scaleUpEvents, scaleEvent{time: t, change: change})
+	} else if change < 0 {
+		a.scaleDownEvents = append(a.scaleDownEvents, scaleEvent{time: t, change: -change})
+	}
+	a.scaleUpEvents = pruneEvents(t, a.scaleUpEvents, a.scaleUp)
+	a.scaleDownEvents = pruneEvents(t, a.scaleDownEvents, a.scaleDown)
+	a.replicas = desired
+	return desired
+}
+
+// pruneEvents drops the events older than the longest period of the policies of rules.
+func pruneEvents(t time.Time, events []scaleEvent, rules *autoscalingv2.HPAScalingRules) []scaleEvent {
+	var longest int32
+	for _, policy := range rules.Policies {
+		if policy.PeriodSeconds > longest {
+			longest = policy.PeriodSeconds
+		}
+	}
+	period := time.Duration(longest) * time.Second
+	kept := events[:0]
+	for _, e := range events {
+		if t.Sub(e.time) < period {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// stabilize records recommended and returns the recommendation stabilized by the windows of both directions:
+// the least recommendation of the scale up window bounds a scale up, and the largest recommendation
+// of the scale down window bounds a scale down.
+func (a *autoscaler) stabilize(t time.Time, recommended int32) int32 {
+	upWindow := time.Duration(*a.scaleUp.StabilizationWindowSeconds) * time.Second
+	downWindow := time.Duration(*a.scaleDown.StabilizationWindowSeconds) * time.Second
+	longest := upWindow
+	if downWindow > longest {
+		longest = downWindow
+	}
+
+	upRecommendation, downRecommendation := recommended, recommended
+	kept := a.recommendations[:0]
+	for _, r := range a.recommendations {
+		age := t.Sub(r.time)
+		if age > longest {
+			continue
+		}
+		kept = append(kept, r)
+		if age < upWindow && r.replicas < upRecommendation {
+			upRecommendation = r.replicas
+		}
+		if age < downWindow && r.replicas > downRecommendation {
+			downRecommendation = r.replicas
+		}
+	}
+	a.recommendations = append(kept, recommendation{time: t, replicas: recommended})
+
+	stabilized := a.replicas
+	if upRecommendation > stabilized {
+		stabilized = upRecommendation
+	}
+	if downRecommendation < stabilized {
+		stabilized = downRecommendation
+	}
+	return stabilized
+}
+
+// limit returns desired limited by the scaling policies and the replica bounds.
+func (a *autoscaler) limit(t time.Time, desired int32) int32 {
+	current := a.replicas
+	if desired > current {
+		limit := scaleUpLimit(t, current, a.scaleUpEvents, a.scaleUp)
+		if limit < current {
+			limit = current
+		}
+		if limit > a.maxReplicas {
+			limit = a.maxReplicas
+		}
+		if desired > limit {
+			desired = limit
+		}
+	} else if desired < current {
+		limit := scaleDownLimit(t, current, a.scaleDownEvents, a.scaleDown)
+		if limit > current {
+			limit = current
+		}
+		if limit < a.minReplicas {
+			limit = a.minReplicas
+		}
+		if desired < limit {
+			desired = limit
+		}
+	}
+	return desired
+}
+
+// changeInPeriod returns the sum of the changes of events younger than periodSeconds.
+func changeInPeriod(t time.Time, events []scaleEvent, periodSeconds int32) int32 {
+	period := time.Duration(periodSeconds) * time.Second
+	var change int32
+	for _, e := range events {
+		if t.Sub(e.time) < period {
+			change += e.change
+		}
+	}
+	return change
+}
+
+// scaleUpLimit returns the largest replicas the scale up policies allow at t.
+func scaleUpLimit(t time.Time, current int32, events []scaleEvent, rules *autoscalingv2.HPAScalingRules) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+	result, selectMax := int32(math.MinInt32), *rules.SelectPolicy != autoscalingv2.MinPolicySelect
+	if !selectMax {
+		result = math.MaxInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStart := current - changeInPeriod(t, events, policy.PeriodSeconds)
+		var proposed int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStart + policy.Value
+		} else {
+			proposed = int32(math.Ceil(float64(periodStart) * (1 + float64(policy.Value)/100)))
+		}
+		if (selectMax && proposed > result) || (!selectMax && proposed < result) {
+			result = proposed
+		}
+	}
+	return result
+}
+
+// scaleDownLimit returns the least replicas the scale down policies allow at t.
+func scaleDownLimit(t time.Time, current int32, events []scaleEvent, rules *autoscalingv2.HPAScalingRules) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return current
+	}
+	// Selecting the policy that allows the largest change selects the least limit.
+	result, selectMax := int32(math.MaxInt32), *rules.SelectPolicy != autoscalingv2.MinPolicySelect
+	if !selectMax {
+		result = math.MinInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStart := current + changeInPeriod(t, events, policy.PeriodSeconds)
+		var proposed int32
+		if policy.Type == autoscalingv2.PodsScalingPolicy {
+			proposed = periodStart - policy.Value
+		} else {
+			proposed = int32(float64(periodStart) * (1 - float64(policy.Value)/100))
+		}
+		if (selectMax && proposed < result) || (!selectMax && proposed > result) {
+			result = proposed
+		}
+	}
+	return result
+}
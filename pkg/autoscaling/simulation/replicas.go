@@ -0,0 +1,109 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// calculator computes the replicas proposed by a metric, like the replica calculator of the HorizontalPodAutoscaler controller.
+type calculator struct {
+	tolerance         float64
+	podRequests       corev1.ResourceList
+	containerRequests map[string]corev1.ResourceList
+}
+
+// replicas returns the replicas proposed by metric given its recorded value and the current replicas.
+func (c *calculator) replicas(metric *autoscalingv2.MetricSpec, value float64, current int32) (int32, error) {
+	if current <= 0 {
+		// Autoscaling is disabled while the target is scaled to zero.
+		return current, nil
+	}
+
+	var ratio float64
+	var err error
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource == nil {
+			return 0, fmt.Errorf("resource metric has no source")
+		}
+		ratio, err = c.podRatio(metric.Resource.Target, value, current, c.podRequests, metric.Resource.Name)
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource == nil {
+			return 0, fmt.Errorf("container resource metric has no source")
+		}
+		ratio, err = c.podRatio(metric.ContainerResource.Target, value, current, c.containerRequests[metric.ContainerResource.Container], metric.ContainerResource.Name)
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods == nil {
+			return 0, fmt.Errorf("pods metric has no source")
+		}
+		ratio, err = c.podRatio(metric.Pods.Target, value, current, nil, "")
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object == nil {
+			return 0, fmt.Errorf("object metric has no source")
+		}
+		ratio, err = valueRatio(metric.Object.Target, value, current)
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External == nil {
+			return 0, fmt.Errorf("external metric has no source")
+		}
+		ratio, err = valueRatio(metric.External.Target, value, current)
+	default:
+		return 0, fmt.Errorf("unsupported metric type %q", metric.Type)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if math.Abs(ratio-1) <= c.tolerance {
+		return current, nil
+	}
+	return ceil(ratio * float64(current)), nil
+}
+
+// podRatio returns the ratio of the per pod average of a metric recorded as a sum over pods to its target.
+func (c *calculator) podRatio(target autoscalingv2.MetricTarget, total float64, current int32, requests corev1.ResourceList, name corev1.ResourceName) (float64, error) {
+	average := total / float64(current)
+	switch target.Type {
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization == nil {
+			return 0, fmt.Errorf("utilization target of %s has no averageUtilization", name)
+		}
+		request, ok := requests[name]
+		if !ok || request.IsZero() {
+			return 0, fmt.Errorf("requests of %s are required by its utilization target", name)
+		}
+		return average / (request.AsApproximateFloat64() * float64(*target.AverageUtilization) / 100), nil
+	case autoscalingv2.AverageValueMetricType:
+		return ratio(average, target.AverageValue)
+	default:
+		return 0, fmt.Errorf("unsupported target type %q", target.Type)
+	}
+}
+
+// valueRatio returns the ratio of an object or external metric to its target.
+func valueRatio(target autoscalingv2.MetricTarget, value float64, current int32) (float64, error) {
+	switch target.Type {
+	case autoscalingv2.ValueMetricType:
+		return ratio(value, target.Value)
+	case autoscalingv2.AverageValueMetricType:
+		return ratio(value/float64(current), target.AverageValue)
+	default:
+		return 0, fmt.Errorf("unsupported target type %q", target.Type)
+	}
+}
+
+// ratio returns the ratio of value to target.
+func ratio(value float64, target *resource.Quantity) (float64, error) {
+	if target == nil {
+		return 0, fmt.Errorf("target has no value")
+	}
+	t := target.AsApproximateFloat64()
+	if t == 0 {
+		return 0, fmt.Errorf("target is zero")
+	}
+	return value / t, nil
+}
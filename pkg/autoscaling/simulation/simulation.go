@@ -0,0 +1,218 @@
+// Package simulation replays recorded metrics against an EffectiveHorizontalPodAutoscalerSpec
+// and returns the replicas the autoscaler would have chosen over time.
+//
+// The simulated autoscaler follows the HorizontalPodAutoscaler controller: every sync period
+// each metric proposes replicas, and an active cron proposes its target replicas as one more
+// metric, so it raises the recommendation but never lowers it. The largest proposal wins, and
+// the recommendation is stabilized, rate limited by the Behavior policies and clamped to the
+// replica bounds.
+//
+// Recorded series describe the load rather than its per pod average, so that the load is
+// spread over the simulated replicas instead of the replicas that served it:
+//
+//	Resource, ContainerResource, Pods: the sum of the metric over all pods of the target
+//	Object, External:                  the value of the metric
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	"github.com/gocrane/api/pkg/autoscaling/cron"
+	"github.com/gocrane/api/pkg/autoscaling/hpa"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+const (
+	// DefaultInterval is the default sync period of the HorizontalPodAutoscaler controller.
+	DefaultInterval = 15 * time.Second
+	// DefaultTolerance is the default tolerance of the HorizontalPodAutoscaler controller.
+	DefaultTolerance = 0.1
+)
+
+// Options configure a simulation.
+type Options struct {
+	// InitialReplicas are the replicas of the target at the first step, MinReplicas when zero.
+	InitialReplicas int32
+	// Interval is the duration between two steps, DefaultInterval when zero.
+	Interval time.Duration
+	// Tolerance is the ratio below which a metric does not propose a change, DefaultTolerance when zero.
+	Tolerance float64
+	// PodRequests are the resource requests of a pod of the target, used by resource utilization targets.
+	PodRequests corev1.ResourceList
+	// ContainerRequests are the resource requests of the containers of a pod of the target by container name,
+	// used by container resource utilization targets.
+	ContainerRequests map[string]corev1.ResourceList
+}
+
+// Step is the state of the simulated autoscaler at a point in time.
+type Step struct {
+	// Time is the time of the step.
+	Time time.Time
+	// Recommended are the largest replicas proposed by the metrics and the active crons.
+	Recommended int32
+	// CronActive reports whether a cron is active, and thus proposes its target replicas.
+	CronActive bool
+	// Desired are the replicas chosen by the autoscaler once the recommendation
+	// is stabilized, rate limited and clamped.
+	Desired int32
+	// Replicas are the replicas of the target: Desired with ScaleStrategyAuto, while with
+	// ScaleStrategyPreview they are SpecificReplicas or, if unset, the initial replicas.
+	Replicas int32
+}
+
+// Simulate replays metrics against spec. metrics holds the recorded series of every metric of spec,
+// in their order, or the series of the default metric when spec has none. Steps run every interval
+// from the first to the last recorded sample.
+func Simulate(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec, metrics []*predictionapi.MetricTimeSeries, opts Options) ([]Step, error) {
+	specs := spec.Metrics
+	if len(specs) == 0 {
+		specs = []autoscalingv2.MetricSpec{hpa.DefaultMetric()}
+	}
+	if len(metrics) != len(specs) {
+		return nil, fmt.Errorf("got %d metric series for %d metrics", len(metrics), len(specs))
+	}
+
+	series := make([]*samples, len(metrics))
+	for i, m := range metrics {
+		s, err := newSamples(m)
+		if err != nil {
+			return nil, fmt.Errorf("metric %d: %w", i, err)
+		}
+		series[i] = s
+	}
+	from, until, ok := bounds(series)
+	if !ok {
+		return nil, nil
+	}
+
+	crons, err := cron.NewEvaluator(spec.Crons)
+	if err != nil {
+		return nil, err
+	}
+
+	minReplicas := int32(1)
+	if spec.MinReplicas != nil {
+		minReplicas = *spec.MinReplicas
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	initialReplicas := opts.InitialReplicas
+	if initialReplicas <= 0 {
+		initialReplicas = minReplicas
+	}
+
+	a := newAutoscaler(minReplicas, spec.MaxReplicas, spec.Behavior, initialReplicas)
+	calculator := &calculator{tolerance: tolerance, podRequests: opts.PodRequests, containerRequests: opts.ContainerRequests}
+
+	var steps []Step
+	for t := from; !t.After(until); t = t.Add(interval) {
+		current := a.replicas
+		recommended := current
+		proposed := false
+		for i := range specs {
+			value, ok := series[i].at(t)
+			if !ok {
+				continue
+			}
+			replicas, err := calculator.replicas(&specs[i], value, current)
+			if err != nil {
+				return nil, fmt.Errorf("metric %d: %w", i, err)
+			}
+			if !proposed || replicas > recommended {
+				recommended, proposed = replicas, true
+			}
+		}
+
+		cronResult := crons.Evaluate(t)
+		if target := cronResult.TargetReplicas; target != nil && (!proposed || *target > recommended) {
+			recommended = *target
+		}
+
+		desired := a.scale(t, recommended)
+		replicas := desired
+		if spec.ScaleStrategy == autoscalingapi.ScaleStrategyPreview {
+			replicas = initialReplicas
+			if spec.SpecificReplicas != nil {
+				replicas = *spec.SpecificReplicas
+			}
+		}
+		steps = append(steps, Step{
+			Time:        t,
+			Recommended: recommended,
+			CronActive:  cronResult.TargetReplicas != nil,
+			Desired:     desired,
+			Replicas:    replicas,
+		})
+	}
+	return steps, nil
+}
+
+// samples is a recorded series with parsed values, sorted by time.
+type samples struct {
+	times  []time.Time
+	values []float64
+}
+
+// newSamples parses series, whose timestamps are in seconds since the epoch.
+func newSamples(series *predictionapi.MetricTimeSeries) (*samples, error) {
+	s := &samples{}
+	if series == nil {
+		return s, nil
+	}
+	sorted := append([]predictionapi.Sample(nil), series.Samples...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+	for _, sample := range sorted {
+		value, err := strconv.ParseFloat(sample.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sample at %d: %v", sample.Timestamp, err)
+		}
+		s.times = append(s.times, time.Unix(sample.Timestamp, 0).UTC())
+		s.values = append(s.values, value)
+	}
+	return s, nil
+}
+
+// at returns the value of the last sample at or before t.
+func (s *samples) at(t time.Time) (float64, bool) {
+	i := sort.Search(len(s.times), func(i int) bool { return s.times[i].After(t) })
+	if i == 0 {
+		return 0, false
+	}
+	return s.values[i-1], true
+}
+
+// bounds returns the times of the first and the last samples of series.
+func bounds(series []*samples) (from, until time.Time, ok bool) {
+	for _, s := range series {
+		if len(s.times) == 0 {
+			continue
+		}
+		if first := s.times[0]; !ok || first.Before(from) {
+			from = first
+		}
+		if last := s.times[len(s.times)-1]; !ok || last.After(until) {
+			until = last
+		}
+		ok = true
+	}
+	return from, until, ok
+}
+
+// ceil rounds a replica count up, guarding against floating point noise.
+func ceil(replicas float64) int32 {
+	return int32(math.Ceil(replicas - 1e-9))
+}
@@ -0,0 +1,220 @@
+package simulation
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var start = time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+// series returns a series of values spaced by 15s from start.
+func series(values ...float64) *predictionapi.MetricTimeSeries {
+	s := &predictionapi.MetricTimeSeries{}
+	for i, value := range values {
+		s.Samples = append(s.Samples, predictionapi.Sample{
+			Value:     strconv.FormatFloat(value, 'f', -1, 64),
+			Timestamp: start.Add(time.Duration(i) * 15 * time.Second).Unix(),
+		})
+	}
+	return s
+}
+
+// repeat returns n times value.
+func repeat(value float64, n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+func podsMetric(averageValue int64) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "http_requests"},
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: resource.NewQuantity(averageValue, resource.DecimalSI)},
+		},
+	}
+}
+
+func desired(steps []Step) []int32 {
+	var replicas []int32
+	for _, step := range steps {
+		replicas = append(replicas, step.Desired)
+	}
+	return replicas
+}
+
+func TestSimulateScaleUp(t *testing.T) {
+	minReplicas := int32(1)
+	spec := &autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+		MinReplicas: &minReplicas,
+		MaxReplicas: 20,
+		Metrics:     []autoscalingv2.MetricSpec{podsMetric(100)},
+	}
+
+	steps, err := Simulate(spec, []*predictionapi.MetricTimeSeries{series(200, 1500, 1500, 1500)}, Options{InitialReplicas: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The default scale up policies allow the larger of 4 pods or 100% every 15s.
+	want := []int32{2, 6, 12, 15}
+	if got := desired(steps); !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if steps[1].Recommended != 15 {
+		t.Errorf("got recommendation %d, want 15", steps[1].Recommended)
+	}
+
+	spec.MaxReplicas = 5
+	steps, err = Simulate(spec, []*predictionapi.MetricTimeSeries{series(200, 1500)}, Options{InitialReplicas: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := desired(steps), []int32{2, 5}; !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSimulateScaleDownStabilization(t *testing.T) {
+	spec := &autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+		MaxReplicas: 10,
+		Metrics:     []autoscalingv2.MetricSpec{podsMetric(100)},
+	}
+	values := append([]float64{400}, repeat(100, 21)...)
+
+	steps, err := Simulate(spec, []*predictionapi.MetricTimeSeries{series(values...)}, Options{InitialReplicas: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The recommendation of 4 replicas at 0s leaves the default 300s scale down window at 300s.
+	for _, step := range steps {
+		want := int32(4)
+		if step.Time.Sub(start) >= 300*time.Second {
+			want = 1
+		}
+		if step.Desired != want {
+			t.Errorf("at %s: got %d, want %d", step.Time.Sub(start), step.Desired, want)
+		}
+	}
+
+	window := int32(0)
+	selectPolicy := autoscalingv2.MinPolicySelect
+	spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: &window,
+			SelectPolicy:               &selectPolicy,
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 30},
+				{Type: autoscalingv2.PercentScalingPolicy, Value: 50, PeriodSeconds: 15},
+			},
+		},
+	}
+	steps, err = Simulate(spec, []*predictionapi.MetricTimeSeries{series(values[:6]...)}, Options{InitialReplicas: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The least change among 1 pod per 30s and 50% per 15s.
+	if got, want := desired(steps), []int32{4, 3, 3, 2, 2, 1}; !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSimulateCronPreview(t *testing.T) {
+	specificReplicas := int32(7)
+	spec := &autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+		MaxReplicas:      10,
+		ScaleStrategy:    autoscalingapi.ScaleStrategyPreview,
+		SpecificReplicas: &specificReplicas,
+		Metrics:          []autoscalingv2.MetricSpec{podsMetric(100)},
+		Crons: []autoscalingapi.CronSpec{
+			{Name: "morning", Start: "0 9 * * *", End: "1 10 * * *", TargetReplicas: 3},
+		},
+	}
+
+	steps, err := Simulate(spec, []*predictionapi.MetricTimeSeries{series(repeat(50, 6)...)}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range steps {
+		active := step.Time.Before(start.Add(time.Minute))
+		if step.CronActive != active {
+			t.Errorf("at %s: got cron active %v, want %v", step.Time, step.CronActive, active)
+		}
+		if active && (step.Recommended != 3 || step.Desired != 3) {
+			t.Errorf("at %s: got recommended %d and desired %d, want 3", step.Time, step.Recommended, step.Desired)
+		}
+		if step.Replicas != specificReplicas {
+			t.Errorf("at %s: got replicas %d, want %d", step.Time, step.Replicas, specificReplicas)
+		}
+	}
+	// Once the cron ends, the scale down is stabilized by the recommendations of the cron.
+	if last := steps[len(steps)-1]; last.CronActive || last.Recommended != 1 || last.Desired != 3 {
+		t.Errorf("unexpected last step %+v", last)
+	}
+}
+
+func TestSimulateCronBelowMetrics(t *testing.T) {
+	spec := &autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+		MaxReplicas:   10,
+		ScaleStrategy: autoscalingapi.ScaleStrategyAuto,
+		Metrics:       []autoscalingv2.MetricSpec{podsMetric(100)},
+		Crons: []autoscalingapi.CronSpec{
+			{Name: "morning", Start: "0 9 * * *", End: "0 11 * * *", TargetReplicas: 3},
+		},
+	}
+
+	steps, err := Simulate(spec, []*predictionapi.MetricTimeSeries{series(repeat(500, 6)...)}, Options{InitialReplicas: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The cron only sets a minimum, so it never scales down below the recommendation of the metrics.
+	for _, step := range steps {
+		if !step.CronActive || step.Recommended != 5 || step.Desired != 5 {
+			t.Errorf("at %s: got cron active %v, recommended %d and desired %d, want true, 5 and 5", step.Time, step.CronActive, step.Recommended, step.Desired)
+		}
+	}
+}
+
+func TestSimulateUtilization(t *testing.T) {
+	spec := &autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{MaxReplicas: 10}
+	metrics := []*predictionapi.MetricTimeSeries{series(1, 6)}
+
+	if _, err := Simulate(spec, metrics, Options{}); err == nil {
+		t.Fatal("expected an error without pod requests")
+	}
+	if _, err := Simulate(spec, nil, Options{}); err == nil {
+		t.Fatal("expected an error without the series of the default metric")
+	}
+
+	steps, err := Simulate(spec, metrics, Options{InitialReplicas: 2, PodRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1 core over 2 pods requesting 500m is 100% against the default 80% target, so 3 pods are needed.
+	// 6 cores need 15 pods, limited to 7 by the default scale up policies.
+	if got, want := desired(steps), []int32{3, 7}; !equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func equal(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+// Package validation validates the objects of the autoscaling API group.
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	"github.com/gocrane/api/pkg/autoscaling/cron"
+	predictionvalidation "github.com/gocrane/api/pkg/prediction/validation"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var supportedScaleStrategies = sets.NewString(
+	string(autoscalingapi.ScaleStrategyAuto),
+	string(autoscalingapi.ScaleStrategyPreview),
+)
+
+// ValidateEffectiveHorizontalPodAutoscaler validates an EffectiveHorizontalPodAutoscaler.
+func ValidateEffectiveHorizontalPodAutoscaler(ehpa *autoscalingapi.EffectiveHorizontalPodAutoscaler) field.ErrorList {
+	return ValidateEffectiveHorizontalPodAutoscalerSpec(&ehpa.Spec, field.NewPath("spec"))
+}
+
+// ValidateEffectiveHorizontalPodAutoscalerSpec validates an EffectiveHorizontalPodAutoscalerSpec.
+func ValidateEffectiveHorizontalPodAutoscalerSpec(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ScaleTargetRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("scaleTargetRef", "kind"), ""))
+	}
+	if spec.ScaleTargetRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("scaleTargetRef", "name"), ""))
+	}
+
+	if spec.MinReplicas != nil && *spec.MinReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), *spec.MinReplicas, "must be greater than or equal to 0"))
+	}
+	if spec.MaxReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), spec.MaxReplicas, "must be greater than 0"))
+	}
+	if spec.MinReplicas != nil && *spec.MinReplicas > spec.MaxReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), *spec.MinReplicas, "must be less than or equal to maxReplicas"))
+	}
+
+	if spec.ScaleStrategy != "" && !supportedScaleStrategies.Has(string(spec.ScaleStrategy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("scaleStrategy"), spec.ScaleStrategy, supportedScaleStrategies.List()))
+	}
+	if spec.SpecificReplicas != nil && *spec.SpecificReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("specificReplicas"), *spec.SpecificReplicas, "must be greater than or equal to 0"))
+	}
+
+	names := sets.NewString()
+	for i := range spec.Crons {
+		idxPath := fldPath.Child("crons").Index(i)
+		if spec.Crons[i].Name != "" {
+			if names.Has(spec.Crons[i].Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), spec.Crons[i].Name))
+			}
+			names.Insert(spec.Crons[i].Name)
+		}
+		allErrs = append(allErrs, ValidateCronSpec(&spec.Crons[i], idxPath)...)
+	}
+
+	if spec.Prediction != nil {
+		allErrs = append(allErrs, ValidatePrediction(spec.Prediction, fldPath.Child("prediction"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateCronSpec validates a CronSpec. Start and End must be standard crontab
+// schedules and TimeZone, when set, must be a location of the IANA time zone database.
+func ValidateCronSpec(cronSpec *autoscalingapi.CronSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateSchedule(cronSpec.Start, fldPath.Child("start"))...)
+	allErrs = append(allErrs, validateSchedule(cronSpec.End, fldPath.Child("end"))...)
+	if cronSpec.TimeZone != nil {
+		if _, err := cron.LoadLocation(cronSpec.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("timezone"), *cronSpec.TimeZone, err.Error()))
+		}
+	}
+	if cronSpec.TargetReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetReplicas"), cronSpec.TargetReplicas, "must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+func validateSchedule(schedule string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == "" {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+	} else if _, err := cron.ParseStandard(schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, schedule, err.Error()))
+	}
+	return allErrs
+}
+
+// ValidatePrediction validates a Prediction.
+func ValidatePrediction(prediction *autoscalingapi.Prediction, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if prediction.PredictionWindowSeconds != nil && *prediction.PredictionWindowSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("predictionWindowSeconds"), *prediction.PredictionWindowSeconds, "must be greater than 0"))
+	}
+	if prediction.PredictionAlgorithm != nil {
+		algorithm := &predictionapi.Algorithm{
+			AlgorithmType: prediction.PredictionAlgorithm.AlgorithmType,
+			DSP:           prediction.PredictionAlgorithm.DSP,
+			Percentile:    prediction.PredictionAlgorithm.Percentile,
+			Linear:        prediction.PredictionAlgorithm.Linear,
+			HoltWinters:   prediction.PredictionAlgorithm.HoltWinters,
+		}
+		allErrs = append(allErrs, predictionvalidation.ValidateAlgorithm(algorithm, fldPath.Child("predictionAlgorithm"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateSubstitute validates a Substitute.
+func ValidateSubstitute(substitute *autoscalingapi.Substitute) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	if substitute.Spec.SubstituteTargetRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("substituteTargetRef", "kind"), ""))
+	}
+	if substitute.Spec.SubstituteTargetRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("substituteTargetRef", "name"), ""))
+	}
+	if substitute.Spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), substitute.Spec.Replicas, "must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+// ValidateEffectiveVerticalPodAutoscaler validates an EffectiveVerticalPodAutoscaler.
+func ValidateEffectiveVerticalPodAutoscaler(evpa *autoscalingapi.EffectiveVerticalPodAutoscaler) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	if evpa.Spec.TargetRef == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetRef"), ""))
+	} else {
+		if evpa.Spec.TargetRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "kind"), ""))
+		}
+		if evpa.Spec.TargetRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "name"), ""))
+		}
+	}
+
+	if evpa.Spec.ResourcePolicy != nil {
+		containerNames := sets.NewString()
+		for i, policy := range evpa.Spec.ResourcePolicy.ContainerPolicies {
+			idxPath := fldPath.Child("resourcePolicy", "containerPolicies").Index(i)
+			if containerNames.Has(policy.ContainerName) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("containerName"), policy.ContainerName))
+			}
+			containerNames.Insert(policy.ContainerName)
+			for _, scalingPolicy := range []struct {
+				name   string
+				policy *autoscalingapi.ContainerScalingPolicy
+			}{{"scaleUpPolicy", policy.ScaleUpPolicy}, {"scaleDownPolicy", policy.ScaleDownPolicy}} {
+				if scalingPolicy.policy != nil && scalingPolicy.policy.StabilizationWindowSeconds != nil && *scalingPolicy.policy.StabilizationWindowSeconds < 0 {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child(scalingPolicy.name, "stabilizationWindowSeconds"), *scalingPolicy.policy.StabilizationWindowSeconds, "must be greater than or equal to 0"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
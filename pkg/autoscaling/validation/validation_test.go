@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	autoscalingapi "github.com/gocrane/api/autoscaling/v1alpha1"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestValidateEffectiveHorizontalPodAutoscalerSpec(t *testing.T) {
+	validSpec := func() autoscalingapi.EffectiveHorizontalPodAutoscalerSpec {
+		return autoscalingapi.EffectiveHorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "nginx", APIVersion: "apps/v1"},
+			MinReplicas:    int32Ptr(1),
+			MaxReplicas:    10,
+			ScaleStrategy:  autoscalingapi.ScaleStrategyAuto,
+			Crons: []autoscalingapi.CronSpec{{
+				Name:           "nightly",
+				TimeZone:       stringPtr("Asia/Shanghai"),
+				Start:          "0 22 * * *",
+				End:            "0 6 * * *",
+				TargetReplicas: 2,
+			}},
+			Prediction: &autoscalingapi.Prediction{
+				PredictionWindowSeconds: int32Ptr(3600),
+				PredictionAlgorithm: &autoscalingapi.PredictionAlgorithm{
+					AlgorithmType: predictionapi.AlgorithmTypeDSP,
+					DSP:           &predictionapi.DSP{SampleInterval: "60s", HistoryLength: "7d"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec)
+		wantErrs []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec) {},
+		},
+		{
+			name: "min replicas greater than max replicas",
+			mutate: func(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec) {
+				spec.MinReplicas = int32Ptr(11)
+			},
+			wantErrs: []string{"spec.minReplicas"},
+		},
+		{
+			name: "invalid cron schedule and time zone",
+			mutate: func(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec) {
+				spec.Crons[0].Start = "0 25 * * *"
+				spec.Crons[0].TimeZone = stringPtr("Mars/Olympus")
+			},
+			wantErrs: []string{"spec.crons[0].start", "spec.crons[0].timezone"},
+		},
+		{
+			name: "invalid prediction algorithm",
+			mutate: func(spec *autoscalingapi.EffectiveHorizontalPodAutoscalerSpec) {
+				spec.Prediction.PredictionAlgorithm.DSP.HistoryLength = "a week"
+				spec.Prediction.PredictionAlgorithm.DSP.Bounds = &predictionapi.Bounds{ConfidenceLevel: "1"}
+				spec.Prediction.PredictionAlgorithm.Percentile = &predictionapi.Percentile{Percentile: "1.5", MarginFraction: "ten", Bounds: &predictionapi.Bounds{}}
+			},
+			wantErrs: []string{
+				"spec.prediction.predictionAlgorithm.dsp.historyLength",
+				"spec.prediction.predictionAlgorithm.dsp.bounds.confidenceLevel",
+				"spec.prediction.predictionAlgorithm.percentile.marginFraction",
+				"spec.prediction.predictionAlgorithm.percentile.percentile",
+				"spec.prediction.predictionAlgorithm.percentile.bounds.confidenceLevel",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.mutate(&spec)
+			errs := ValidateEffectiveHorizontalPodAutoscalerSpec(&spec, field.NewPath("spec"))
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("got errors %v, want errors on %v", errs, tt.wantErrs)
+			}
+			for i, err := range errs {
+				if err.Field != tt.wantErrs[i] {
+					t.Errorf("got error on %s, want %s", err.Field, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package validation validates the objects of the co2e API group.
+package validation
+
+import (
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	co2eapi "github.com/gocrane/api/co2e/v1alpha1"
+	utilvalidation "github.com/gocrane/api/pkg/util/validation"
+)
+
+// ValidateCloudCarbonFootprint validates a CloudCarbonFootprint. All numeric strings must parse as non-negative numbers.
+func ValidateCloudCarbonFootprint(ccf *co2eapi.CloudCarbonFootprint) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	spec := &ccf.Spec
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(spec.PUE, fldPath.Child("pue"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(spec.EmissionFactor, fldPath.Child("emissionFactor"))...)
+
+	for i, config := range spec.ComputeConfig {
+		if config == nil {
+			continue
+		}
+		idxPath := fldPath.Child("computeConfig").Index(i)
+		if config.NodeSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(config.NodeSelector, idxPath.Child("nodeSelector"))...)
+		}
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(config.MinWattsPerCPU, idxPath.Child("minWattsPerCPU"))...)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(config.MaxWattsPerCPU, idxPath.Child("maxWattsPerCPU"))...)
+		allErrs = append(allErrs, utilvalidation.ValidateFraction(config.CPUEnergyConsumptionRatio, idxPath.Child("cpuEnergyConsumptionRatio"))...)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(config.MemoryWattsPerGB, idxPath.Child("memoryWattsPerGB"))...)
+	}
+	for i, config := range spec.StorageConfig {
+		if config == nil {
+			continue
+		}
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(config.WattsPerTB, fldPath.Child("storageConfig").Index(i).Child("wattsPerTB"))...)
+	}
+	for i, config := range spec.NetworkingConfig {
+		if config == nil {
+			continue
+		}
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(config.WattsPerGB, fldPath.Child("networkingConfig").Index(i).Child("wattsPerGB"))...)
+	}
+
+	return allErrs
+}
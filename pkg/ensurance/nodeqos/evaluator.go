@@ -0,0 +1,142 @@
+package nodeqos
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+// DefaultCoolDownSeconds is the cool down of the rules whose action does not set one.
+const DefaultCoolDownSeconds = 300
+
+// Observation is an observed value of the metric of a rule.
+type Observation struct {
+	Time  time.Time
+	Value resource.Quantity
+}
+
+// Evaluator is the state machine of a rule on a node.
+//
+// An observation reaches the rule when its value is greater than or equal to MetricRule.Value. The rule
+// triggers when AvoidanceThreshold consecutive observations reach it, and restores when RestoreThreshold
+// consecutive observations do not. Zero thresholds count as 1. A rule restores as soon as it meets its
+// RestoreThreshold, but it does not trigger again within the CoolDownSeconds of the action of the rule
+// after the previous transition: the hit count keeps growing, and the rule triggers on the first
+// observation after the cool down if the count still meets the threshold.
+// A rule with the Preview strategy transitions the same way, with its transitions marked as previews.
+//
+// Observations not after the previous one are ignored, so an evaluator yields the same transitions
+// from the same observations.
+type Evaluator struct {
+	rule     ensuranceapi.Rule
+	coolDown time.Duration
+	status   ensuranceapi.RuleStatus
+	last     time.Time
+}
+
+// NewEvaluator returns an evaluator of rule in the Normal state. action is the avoidance action of the
+// rule, nil if unknown, in which case the cool down is DefaultCoolDownSeconds.
+func NewEvaluator(rule *ensuranceapi.Rule, action *ensuranceapi.AvoidanceAction) *Evaluator {
+	return Resume(rule, action, ensuranceapi.RuleStatus{Name: rule.Name, State: ensuranceapi.RuleStateNormal})
+}
+
+// Resume returns an evaluator of rule in the state status, such as the state last reported by a node.
+func Resume(rule *ensuranceapi.Rule, action *ensuranceapi.AvoidanceAction, status ensuranceapi.RuleStatus) *Evaluator {
+	coolDownSeconds := int32(DefaultCoolDownSeconds)
+	if action != nil && action.Spec.CoolDownSeconds > 0 {
+		coolDownSeconds = action.Spec.CoolDownSeconds
+	}
+	e := &Evaluator{
+		rule:     *rule.DeepCopy(),
+		coolDown: time.Duration(coolDownSeconds) * time.Second,
+		status:   *status.DeepCopy(),
+	}
+	if e.status.State == "" {
+		e.status.State = ensuranceapi.RuleStateNormal
+	}
+	e.status.Name = rule.Name
+	e.status.ActionName = rule.AvoidanceActionName
+	e.status.Preview = rule.Strategy == ensuranceapi.AvoidanceActionStrategyPreview
+	return e
+}
+
+// Status returns the current state of the rule.
+func (e *Evaluator) Status() ensuranceapi.RuleStatus {
+	return *e.status.DeepCopy()
+}
+
+// Observe updates the state of the rule with o, and returns the transition it causes, nil if none.
+// The NodeName of the transition is left to the caller.
+func (e *Evaluator) Observe(o Observation) *ensuranceapi.RuleTransition {
+	if !e.last.IsZero() && !o.Time.After(e.last) {
+		return nil
+	}
+	e.last = o.Time
+
+	value := o.Value.DeepCopy()
+	e.status.Value = &value
+	if e.reaches(o.Value) {
+		e.status.HitCount++
+		e.status.RestoreCount = 0
+	} else {
+		e.status.RestoreCount++
+		e.status.HitCount = 0
+	}
+	now := metav1.NewTime(o.Time)
+	transitionValue := o.Value.DeepCopy()
+	switch {
+	case e.status.State == ensuranceapi.RuleStateNormal && e.status.HitCount >= threshold(e.rule.AvoidanceThreshold) && !e.coolingDown(o.Time):
+		e.status.State = ensuranceapi.RuleStateTriggered
+		e.status.LastTriggeredTime = &now
+	case e.status.State == ensuranceapi.RuleStateTriggered && e.status.RestoreCount >= threshold(e.rule.RestoreThreshold):
+		e.status.State = ensuranceapi.RuleStateNormal
+		e.status.LastRestoredTime = &now
+	default:
+		return nil
+	}
+	return &ensuranceapi.RuleTransition{
+		RuleName:   e.rule.Name,
+		State:      e.status.State,
+		Time:       now,
+		Value:      &transitionValue,
+		ActionName: e.status.ActionName,
+		Preview:    e.status.Preview,
+	}
+}
+
+// Evaluate returns the transitions of rule, from the Normal state, for observations.
+func Evaluate(rule *ensuranceapi.Rule, action *ensuranceapi.AvoidanceAction, observations []Observation) []ensuranceapi.RuleTransition {
+	e := NewEvaluator(rule, action)
+	var transitions []ensuranceapi.RuleTransition
+	for _, o := range observations {
+		if transition := e.Observe(o); transition != nil {
+			transitions = append(transitions, *transition)
+		}
+	}
+	return transitions
+}
+
+// reaches reports whether value reaches the value of the rule.
+func (e *Evaluator) reaches(value resource.Quantity) bool {
+	return e.rule.MetricRule != nil && value.Cmp(e.rule.MetricRule.Value) >= 0
+}
+
+// coolingDown reports whether t is within the cool down of the previous transition.
+func (e *Evaluator) coolingDown(t time.Time) bool {
+	for _, previous := range []*metav1.Time{e.status.LastTriggeredTime, e.status.LastRestoredTime} {
+		if previous != nil && t.Sub(previous.Time) < e.coolDown {
+			return true
+		}
+	}
+	return false
+}
+
+func threshold(t int32) int32 {
+	if t < 1 {
+		return 1
+	}
+	return t
+}
@@ -0,0 +1,214 @@
+package nodeqos
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+func TestSetStatus(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	transition := func(minute int, state ensuranceapi.RuleState) ensuranceapi.RuleTransition {
+		return ensuranceapi.RuleTransition{NodeName: "node-a", RuleName: "cpu", State: state, Time: metav1.NewTime(start.Add(time.Duration(minute) * time.Minute))}
+	}
+
+	status := &ensuranceapi.NodeQOSStatus{}
+	SetStatus(status, 1, []ensuranceapi.NodeQOSNodeStatus{
+		{NodeName: "node-c", Rules: []ensuranceapi.RuleStatus{{Name: "cpu", State: ensuranceapi.RuleStateNormal}}},
+		{NodeName: "node-b", Rules: []ensuranceapi.RuleStatus{{Name: "cpu", State: ensuranceapi.RuleStateTriggered, Preview: true}}},
+		{NodeName: "node-a", Rules: []ensuranceapi.RuleStatus{
+			{Name: "cpu", State: ensuranceapi.RuleStateTriggered},
+			{Name: "memory", State: ensuranceapi.RuleStateNormal},
+		}},
+	}, transition(0, ensuranceapi.RuleStateTriggered))
+
+	if status.NodeNumber != 3 || status.TriggeredNodes != 2 || status.TriggeredRules != 2 || len(status.Nodes) != 2 || status.Nodes[0].NodeName != "node-a" {
+		t.Fatalf("got %d nodes, %d triggered nodes, %d triggered rules and nodes %v, want 3, 2, 2 and the sorted triggered nodes",
+			status.NodeNumber, status.TriggeredNodes, status.TriggeredRules, status.Nodes)
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, string(ensuranceapi.NodeQOSConditionTriggered)) ||
+		!meta.IsStatusConditionTrue(status.Conditions, string(ensuranceapi.NodeQOSConditionPreviewed)) {
+		t.Errorf("got conditions %v, want Triggered and Previewed", status.Conditions)
+	}
+	if len(status.History) != 1 {
+		t.Errorf("got history %v, want the transition", status.History)
+	}
+
+	for minute := 1; minute <= MaxHistory+5; minute++ {
+		RecordTransitions(status, transition(minute, ensuranceapi.RuleStateNormal))
+	}
+	if len(status.History) != MaxHistory || !status.History[0].Time.Equal(&metav1.Time{Time: start.Add(time.Duration(MaxHistory+5) * time.Minute)}) {
+		t.Errorf("got %d transitions starting at %v, want the %d most recent", len(status.History), status.History[0].Time, MaxHistory)
+	}
+
+	var nodes []ensuranceapi.NodeQOSNodeStatus
+	for i := 0; i < MaxTriggeredNodeSamples+2; i++ {
+		nodes = append(nodes, ensuranceapi.NodeQOSNodeStatus{NodeName: fmt.Sprintf("node-%02d", i), Rules: []ensuranceapi.RuleStatus{
+			{Name: "cpu", State: ensuranceapi.RuleStateTriggered},
+		}})
+	}
+	SetStatus(status, 2, nodes)
+	if status.NodeNumber != MaxTriggeredNodeSamples+2 || status.TriggeredNodes != MaxTriggeredNodeSamples+2 || len(status.Nodes) != MaxTriggeredNodeSamples {
+		t.Errorf("got %d nodes, %d triggered nodes and %d node samples, want %d, %d and %d",
+			status.NodeNumber, status.TriggeredNodes, len(status.Nodes), MaxTriggeredNodeSamples+2, MaxTriggeredNodeSamples+2, MaxTriggeredNodeSamples)
+	}
+	if condition := meta.FindStatusCondition(status.Conditions, string(ensuranceapi.NodeQOSConditionTriggered)); !strings.HasSuffix(condition.Message, "node-09/cpu and 2 more") {
+		t.Errorf("got message %q, want the first %d rules and the number of the others", condition.Message, MaxTriggeredNodeSamples)
+	}
+
+	SetStatus(status, 3, []ensuranceapi.NodeQOSNodeStatus{
+		{NodeName: "node-a", Rules: []ensuranceapi.RuleStatus{{Name: "cpu", State: ensuranceapi.RuleStateNormal}}},
+	})
+	if status.NodeNumber != 1 || status.TriggeredNodes != 0 || status.TriggeredRules != 0 || len(status.Nodes) != 0 || status.ObservedGeneration != 3 {
+		t.Errorf("got %d nodes, %d triggered nodes, %d triggered rules, nodes %v and generation %d, want 1, 0, 0, none and 3",
+			status.NodeNumber, status.TriggeredNodes, status.TriggeredRules, status.Nodes, status.ObservedGeneration)
+	}
+	if meta.IsStatusConditionTrue(status.Conditions, string(ensuranceapi.NodeQOSConditionTriggered)) ||
+		meta.IsStatusConditionTrue(status.Conditions, string(ensuranceapi.NodeQOSConditionPreviewed)) {
+		t.Errorf("got conditions %v, want neither Triggered nor Previewed", status.Conditions)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	type transition struct {
+		minute int
+		state  ensuranceapi.RuleState
+	}
+	triggered := func(minute int) transition { return transition{minute, ensuranceapi.RuleStateTriggered} }
+	restored := func(minute int) transition { return transition{minute, ensuranceapi.RuleStateNormal} }
+
+	tests := []struct {
+		name               string
+		avoidanceThreshold int32
+		restoreThreshold   int32
+		coolDownSeconds    int32
+		strategy           ensuranceapi.AvoidanceActionStrategy
+		values             []int64
+		want               []transition
+	}{
+		{
+			name:   "default thresholds",
+			values: []int64{50, 80, 90, 50},
+			want:   []transition{triggered(1), restored(3)},
+		},
+		{
+			name:               "avoidance threshold",
+			avoidanceThreshold: 3,
+			values:             []int64{90, 90, 50, 90, 90, 90, 90},
+			want:               []transition{triggered(5)},
+		},
+		{
+			name:             "restore threshold",
+			restoreThreshold: 2,
+			values:           []int64{90, 50, 90, 50, 50, 50},
+			want:             []transition{triggered(0), restored(4)},
+		},
+		{
+			name:            "restore during the cool down",
+			coolDownSeconds: 300,
+			values:          []int64{90, 50, 50, 50, 50, 50, 50},
+			want:            []transition{triggered(0), restored(1)},
+		},
+		{
+			name:            "hit count growing during the cool down",
+			coolDownSeconds: 180,
+			values:          []int64{90, 50, 90, 90, 90, 90},
+			want:            []transition{triggered(0), restored(1), triggered(4)},
+		},
+		{
+			name:            "hit count reset during the cool down",
+			coolDownSeconds: 180,
+			values:          []int64{90, 50, 90, 90, 50, 90},
+			want:            []transition{triggered(0), restored(1), triggered(5)},
+		},
+		{
+			name:            "trigger again after the cool down",
+			coolDownSeconds: 120,
+			values:          []int64{90, 50, 50, 90, 90, 90},
+			want:            []transition{triggered(0), restored(1), triggered(3)},
+		},
+		{
+			name:     "preview",
+			strategy: ensuranceapi.AvoidanceActionStrategyPreview,
+			values:   []int64{90, 50},
+			want:     []transition{triggered(0), restored(1)},
+		},
+		{
+			name:   "never reached",
+			values: []int64{10, 20, 79},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := &ensuranceapi.Rule{
+				Name:                "cpu",
+				MetricRule:          &ensuranceapi.MetricRule{Name: "cpu_total_usage", Value: resource.MustParse("80")},
+				AvoidanceThreshold:  tt.avoidanceThreshold,
+				RestoreThreshold:    tt.restoreThreshold,
+				AvoidanceActionName: "throttle",
+				Strategy:            tt.strategy,
+			}
+			coolDownSeconds := tt.coolDownSeconds
+			if coolDownSeconds == 0 {
+				coolDownSeconds = 1
+			}
+			action := &ensuranceapi.AvoidanceAction{Spec: ensuranceapi.AvoidanceActionSpec{CoolDownSeconds: coolDownSeconds}}
+			var observations []Observation
+			for i, value := range tt.values {
+				observations = append(observations, Observation{Time: start.Add(time.Duration(i) * time.Minute), Value: *resource.NewQuantity(value, resource.DecimalSI)})
+			}
+
+			got := Evaluate(rule, action, observations)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got transitions %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				wantTime := start.Add(time.Duration(want.minute) * time.Minute)
+				if got[i].State != want.state || !got[i].Time.Time.Equal(wantTime) {
+					t.Errorf("got transition %d to %s at %v, want to %s at %v", i, got[i].State, got[i].Time, want.state, wantTime)
+				}
+				if got[i].RuleName != "cpu" || got[i].ActionName != "throttle" || got[i].Preview != (tt.strategy == ensuranceapi.AvoidanceActionStrategyPreview) {
+					t.Errorf("got transition %+v, want rule cpu, action throttle and preview for the Preview strategy", got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule := &ensuranceapi.Rule{Name: "cpu", MetricRule: &ensuranceapi.MetricRule{Value: resource.MustParse("80")}, RestoreThreshold: 2}
+	triggeredTime := metav1.NewTime(start)
+	e := Resume(rule, nil, ensuranceapi.RuleStatus{State: ensuranceapi.RuleStateTriggered, RestoreCount: 1, LastTriggeredTime: &triggeredTime})
+
+	// The restore does not wait for the cool down.
+	transition := e.Observe(Observation{Time: start.Add(time.Minute), Value: resource.MustParse("50")})
+	if transition == nil || transition.State != ensuranceapi.RuleStateNormal {
+		t.Fatalf("got transition %+v, want a restore during the cool down", transition)
+	}
+	if status := e.Status(); status.State != ensuranceapi.RuleStateNormal || status.LastRestoredTime == nil || status.Value.String() != "50" {
+		t.Errorf("got status %+v, want restored with value 50", status)
+	}
+	// Observations out of order are ignored.
+	if transition := e.Observe(Observation{Time: start, Value: resource.MustParse("90")}); transition != nil || e.Status().HitCount != 0 {
+		t.Errorf("got transition %+v and hit count %d for an observation out of order, want none and 0", transition, e.Status().HitCount)
+	}
+	// The default cool down of 300s from the restore at minute 1 holds the next trigger until minute 6.
+	for minute := 2; minute < 6; minute++ {
+		if transition := e.Observe(Observation{Time: start.Add(time.Duration(minute) * time.Minute), Value: resource.MustParse("90")}); transition != nil {
+			t.Errorf("got transition %+v at minute %d during the cool down", transition, minute)
+		}
+	}
+	if transition := e.Observe(Observation{Time: start.Add(6 * time.Minute), Value: resource.MustParse("90")}); transition == nil || transition.State != ensuranceapi.RuleStateTriggered {
+		t.Errorf("got transition %+v, want a trigger after the cool down", transition)
+	}
+}
@@ -0,0 +1,108 @@
+// Package nodeqos evaluates the rules of NodeQOS and maintains their state in the status of NodeQOS.
+package nodeqos
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+// MaxHistory is the maximum number of transitions in the History of the status of a NodeQOS.
+const MaxHistory = 20
+
+// MaxTriggeredNodeSamples is the maximum number of nodes in the Nodes of the status of a NodeQOS, and of
+// triggered rules listed in the messages of its conditions.
+const MaxTriggeredNodeSamples = 10
+
+// Reasons of the conditions of NodeQOS.
+const (
+	ReasonRulesTriggered  = "RulesTriggered"
+	ReasonNoRuleTriggered = "NoRuleTriggered"
+)
+
+// SetStatus sets status for the generation of a NodeQOS from the state of the rules on every node
+// reporting it, and records the transitions of the rules since the previous states. Only a bounded
+// sample of the nodes with triggered rules is kept in Nodes, the numbers count all of them.
+func SetStatus(status *ensuranceapi.NodeQOSStatus, generation int64, nodes []ensuranceapi.NodeQOSNodeStatus, transitions ...ensuranceapi.RuleTransition) {
+	nodes = append([]ensuranceapi.NodeQOSNodeStatus(nil), nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeName < nodes[j].NodeName })
+
+	var samples []ensuranceapi.NodeQOSNodeStatus
+	var triggered, previewed []string
+	var triggeredNodes int32
+	for _, node := range nodes {
+		found := false
+		for _, rule := range node.Rules {
+			if rule.State != ensuranceapi.RuleStateTriggered {
+				continue
+			}
+			found = true
+			if rule.Preview {
+				previewed = append(previewed, node.NodeName+"/"+rule.Name)
+			} else {
+				triggered = append(triggered, node.NodeName+"/"+rule.Name)
+			}
+		}
+		if !found {
+			continue
+		}
+		triggeredNodes++
+		if len(samples) < MaxTriggeredNodeSamples {
+			samples = append(samples, node)
+		}
+	}
+
+	status.ObservedGeneration = generation
+	status.NodeNumber = int32(len(nodes))
+	status.TriggeredNodes = triggeredNodes
+	status.TriggeredRules = int32(len(triggered) + len(previewed))
+	status.Nodes = samples
+	RecordTransitions(status, transitions...)
+	meta.SetStatusCondition(&status.Conditions, condition(ensuranceapi.NodeQOSConditionTriggered, triggered, "actions are taken for rules ", generation))
+	meta.SetStatusCondition(&status.Conditions, condition(ensuranceapi.NodeQOSConditionPreviewed, previewed, "actions are previewed for rules ", generation))
+}
+
+// RecordTransitions adds transitions to the history of status, keeping the MaxHistory most recent.
+func RecordTransitions(status *ensuranceapi.NodeQOSStatus, transitions ...ensuranceapi.RuleTransition) {
+	if len(transitions) == 0 {
+		return
+	}
+	history := append(append([]ensuranceapi.RuleTransition(nil), transitions...), status.History...)
+	sort.SliceStable(history, func(i, j int) bool { return history[j].Time.Before(&history[i].Time) })
+	if len(history) > MaxHistory {
+		history = history[:MaxHistory]
+	}
+	status.History = history
+}
+
+func condition(conditionType ensuranceapi.NodeQOSConditionType, rules []string, message string, generation int64) metav1.Condition {
+	if len(rules) == 0 {
+		return metav1.Condition{
+			Type:               string(conditionType),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             ReasonNoRuleTriggered,
+			Message:            "no rule is triggered",
+		}
+	}
+	return metav1.Condition{
+		Type:               string(conditionType),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             ReasonRulesTriggered,
+		Message:            message + join(rules),
+	}
+}
+
+// join joins the first MaxTriggeredNodeSamples rules, followed by the number of the others.
+func join(rules []string) string {
+	if len(rules) <= MaxTriggeredNodeSamples {
+		return strings.Join(rules, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(rules[:MaxTriggeredNodeSamples], ", "), len(rules)-MaxTriggeredNodeSamples)
+}
@@ -0,0 +1,142 @@
+package podqos
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+// Sources maps the path of every set field of an effective ResourceQOS, such as "cpuQOS.cpuPriority", to
+// the name of the PodQOS it was taken from. It is the value of the AnnotationPodQOSSources annotation.
+type Sources map[string]string
+
+// Annotation returns the value of the AnnotationPodQOSSources annotation for s.
+func (s Sources) Annotation() string {
+	// Marshaling a map of strings cannot fail, and sorts its keys.
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// ParseSources parses the value of the AnnotationPodQOSSources annotation.
+func ParseSources(annotation string) (Sources, error) {
+	s := Sources{}
+	if err := json.Unmarshal([]byte(annotation), &s); err != nil {
+		return nil, fmt.Errorf("annotation %s: %w", ensuranceapi.AnnotationPodQOSSources, err)
+	}
+	return s, nil
+}
+
+// SortByPrecedence sorts podQOSs by decreasing precedence, then by name.
+func SortByPrecedence(podQOSs []*ensuranceapi.PodQOS) {
+	sort.SliceStable(podQOSs, func(i, j int) bool {
+		if podQOSs[i].Spec.Precedence != podQOSs[j].Spec.Precedence {
+			return podQOSs[i].Spec.Precedence > podQOSs[j].Spec.Precedence
+		}
+		return podQOSs[i].Name < podQOSs[j].Name
+	})
+}
+
+// Match returns the PodQOS of podQOSs that match pod, sorted by precedence.
+func Match(podQOSs []*ensuranceapi.PodQOS, pod *corev1.Pod) ([]*ensuranceapi.PodQOS, error) {
+	var matched []*ensuranceapi.PodQOS
+	for _, podQOS := range podQOSs {
+		ok, err := Matches(podQOS, pod)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, podQOS)
+		}
+	}
+	SortByPrecedence(matched)
+	return matched, nil
+}
+
+// Effective returns the effective ResourceQOS of pod, merged from the PodQOS of podQOSs that match it,
+// and the sources of its fields.
+func Effective(podQOSs []*ensuranceapi.PodQOS, pod *corev1.Pod) (ensuranceapi.ResourceQOS, Sources, error) {
+	matched, err := Match(podQOSs, pod)
+	if err != nil {
+		return ensuranceapi.ResourceQOS{}, nil, err
+	}
+	merged, sources := Merge(matched)
+	return merged, sources, nil
+}
+
+// Merge merges the ResourceQOS of podQOSs, which are expected to match the same pod, field by field:
+// every field of CPUQOS, MemoryQOS, NetIOQOS and DiskIOQOS is taken whole from the PodQOS with the highest
+// precedence that sets it to a non-zero value. A zero value, such as HtIsolation{Enable: false}, cannot
+// override a lower precedence, only a non-nil pointer to a zero value, such as a CPUPriority of 0, can.
+// It returns the merged ResourceQOS and the sources of its fields.
+func Merge(podQOSs []*ensuranceapi.PodQOS) (ensuranceapi.ResourceQOS, Sources) {
+	sorted := append([]*ensuranceapi.PodQOS(nil), podQOSs...)
+	SortByPrecedence(sorted)
+
+	merged := ensuranceapi.ResourceQOS{}
+	sources := Sources{}
+	to := reflect.ValueOf(&merged).Elem()
+	for _, podQOS := range sorted {
+		walk(podQOS.Spec.ResourceQOS.DeepCopy(), func(path string, i, j int, value reflect.Value) {
+			if sources[path] != "" {
+				return
+			}
+			if to.Field(i).IsNil() {
+				to.Field(i).Set(reflect.New(to.Field(i).Type().Elem()))
+			}
+			to.Field(i).Elem().Field(j).Set(value)
+			sources[path] = podQOS.Name
+		})
+	}
+	return merged, sources
+}
+
+// Fields returns the paths of the fields set by qos.
+func Fields(qos *ensuranceapi.ResourceQOS) []string {
+	var paths []string
+	walk(qos, func(path string, _, _ int, _ reflect.Value) {
+		paths = append(paths, path)
+	})
+	return paths
+}
+
+// Overridden returns the paths of the fields set by podQOS that sources takes from other PodQOS.
+func Overridden(podQOS *ensuranceapi.PodQOS, sources Sources) []string {
+	var paths []string
+	for _, path := range Fields(&podQOS.Spec.ResourceQOS) {
+		if source, ok := sources[path]; ok && source != podQOS.Name {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// walk calls fn for every field of the sections of qos set to a non-zero value, with its path and its
+// index in its section and of its section in ResourceQOS.
+func walk(qos *ensuranceapi.ResourceQOS, fn func(path string, i, j int, value reflect.Value)) {
+	from := reflect.ValueOf(qos).Elem()
+	for i := 0; i < from.NumField(); i++ {
+		section := from.Field(i)
+		if section.IsNil() {
+			continue
+		}
+		sectionName := jsonName(from.Type().Field(i))
+		for j := 0; j < section.Elem().NumField(); j++ {
+			if value := section.Elem().Field(j); !value.IsZero() {
+				fn(sectionName+"."+jsonName(section.Elem().Type().Field(j)), i, j, value)
+			}
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return f.Name
+}
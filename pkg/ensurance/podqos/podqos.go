@@ -0,0 +1,230 @@
+// Package podqos decides which PodQOS apply to a pod, so that the agent, the webhook and audit
+// tools agree on it.
+//
+// A pod matches a PodQOS when it matches both its LabelSelector and all the requirements of its
+// ScopeSelector. An empty LabelSelector and a missing ScopeSelector match every pod. The scopes of
+// a requirement are:
+//   - QOSClass: the QOS class of the pod, from its status, or computed from its containers while the
+//     status is not set.
+//   - Namespace: the namespace of the pod.
+//   - Priority: the priority of the pod, 0 when not set. A value is an integer, such as "1000", or an
+//     inclusive range of integers, such as "1000..2000", "..0" or "1000000000..", where a missing bound
+//     is unbounded.
+//
+// With the In operator a requirement matches a pod whose scope matches any of the values, and with
+// NotIn a pod whose scope matches none of them.
+//
+// When several PodQOS match a pod, Merge resolves the conflicts by precedence field by field.
+package podqos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+var (
+	// ErrInvalidPriority is returned for a Priority value that is neither an integer nor a range.
+	ErrInvalidPriority = errors.New("invalid priority")
+	// ErrUnsupportedScope is returned for a requirement with an unknown scope name or operator.
+	ErrUnsupportedScope = errors.New("unsupported scope")
+)
+
+// PriorityRange is an inclusive range of pod priorities.
+type PriorityRange struct {
+	Min int64
+	Max int64
+}
+
+// Contains reports whether r contains priority.
+func (r PriorityRange) Contains(priority int64) bool {
+	return r.Min <= priority && priority <= r.Max
+}
+
+// ParsePriority parses a Priority value: an integer or an inclusive range MIN..MAX with optional bounds.
+func ParsePriority(value string) (PriorityRange, error) {
+	parse := func(s string, unbounded int64) (int64, error) {
+		if s == "" {
+			return unbounded, nil
+		}
+		return strconv.ParseInt(s, 10, 32)
+	}
+
+	min, max, isRange := value, value, false
+	if i := strings.Index(value, ".."); i >= 0 {
+		min, max, isRange = value[:i], value[i+2:], true
+	}
+	if !isRange && value == "" {
+		return PriorityRange{}, fmt.Errorf("%w %q: empty", ErrInvalidPriority, value)
+	}
+	r := PriorityRange{}
+	var err error
+	if r.Min, err = parse(min, math.MinInt32); err != nil {
+		return PriorityRange{}, fmt.Errorf("%w %q: %v", ErrInvalidPriority, value, err)
+	}
+	if r.Max, err = parse(max, math.MaxInt32); err != nil {
+		return PriorityRange{}, fmt.Errorf("%w %q: %v", ErrInvalidPriority, value, err)
+	}
+	if r.Min > r.Max {
+		return PriorityRange{}, fmt.Errorf("%w %q: the minimum is greater than the maximum", ErrInvalidPriority, value)
+	}
+	return r, nil
+}
+
+// Selector is a compiled PodQOS selector.
+type Selector struct {
+	labels       labels.Selector
+	requirements []requirement
+}
+
+type requirement struct {
+	scope    ensuranceapi.ScopeName
+	in       bool
+	values   map[string]bool
+	priority []PriorityRange
+}
+
+// NewSelector compiles the selectors of spec.
+func NewSelector(spec *ensuranceapi.PodQOSSpec) (*Selector, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(&spec.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("label selector: %w", err)
+	}
+	s := &Selector{labels: labelSelector}
+	if spec.ScopeSelector == nil {
+		return s, nil
+	}
+
+	for i, expression := range spec.ScopeSelector.MatchExpressions {
+		r := requirement{scope: expression.ScopeName, values: map[string]bool{}}
+		switch expression.Operator {
+		case corev1.ScopeSelectorOpIn:
+			r.in = true
+		case corev1.ScopeSelectorOpNotIn:
+		default:
+			return nil, fmt.Errorf("scope selector %d: %w operator %q", i, ErrUnsupportedScope, expression.Operator)
+		}
+		switch expression.ScopeName {
+		case ensuranceapi.QOSClassSelector, ensuranceapi.NamespaceSelectors:
+			for _, value := range expression.Values {
+				r.values[value] = true
+			}
+		case ensuranceapi.PrioritySelectors:
+			for _, value := range expression.Values {
+				priority, err := ParsePriority(value)
+				if err != nil {
+					return nil, fmt.Errorf("scope selector %d: %w", i, err)
+				}
+				r.priority = append(r.priority, priority)
+			}
+		default:
+			return nil, fmt.Errorf("scope selector %d: %w name %q", i, ErrUnsupportedScope, expression.ScopeName)
+		}
+		s.requirements = append(s.requirements, r)
+	}
+	return s, nil
+}
+
+// Matches reports whether pod matches s.
+func (s *Selector) Matches(pod *corev1.Pod) bool {
+	if !s.labels.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	for _, r := range s.requirements {
+		if r.matches(pod) != r.in {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the scope of pod matches any value of r.
+func (r *requirement) matches(pod *corev1.Pod) bool {
+	switch r.scope {
+	case ensuranceapi.QOSClassSelector:
+		return r.values[string(QOSClass(pod))]
+	case ensuranceapi.NamespaceSelectors:
+		return r.values[pod.Namespace]
+	case ensuranceapi.PrioritySelectors:
+		priority := Priority(pod)
+		for _, p := range r.priority {
+			if p.Contains(priority) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Matches reports whether pod matches podQOS.
+func Matches(podQOS *ensuranceapi.PodQOS, pod *corev1.Pod) (bool, error) {
+	s, err := NewSelector(&podQOS.Spec)
+	if err != nil {
+		return false, fmt.Errorf("podqos %s: %w", podQOS.Name, err)
+	}
+	return s.Matches(pod), nil
+}
+
+// Priority returns the priority of pod, 0 when it is not set.
+func Priority(pod *corev1.Pod) int64 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return int64(*pod.Spec.Priority)
+}
+
+// QOSClass returns the QOS class of pod from its status, or computed from its containers as the
+// kubelet does while the status is not set.
+func QOSClass(pod *corev1.Pod) corev1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+
+	requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+	guaranteed := true
+	containers := append(append([]corev1.Container(nil), pod.Spec.Containers...), pod.Spec.InitContainers...)
+	for _, container := range containers {
+		for _, list := range []struct {
+			from, to corev1.ResourceList
+		}{{container.Resources.Requests, requests}, {container.Resources.Limits, limits}} {
+			for name, quantity := range list.from {
+				if (name == corev1.ResourceCPU || name == corev1.ResourceMemory) && !quantity.IsZero() {
+					sum := list.to[name]
+					sum.Add(quantity)
+					list.to[name] = sum
+				}
+			}
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			limit, ok := container.Resources.Limits[name]
+			if !ok || limit.IsZero() {
+				guaranteed = false
+				continue
+			}
+			// A missing request defaults to the limit.
+			if request, ok := container.Resources.Requests[name]; ok && !equal(request, limit) {
+				guaranteed = false
+			}
+		}
+	}
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+	if guaranteed {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+func equal(a, b resource.Quantity) bool {
+	return a.Cmp(b) == 0
+}
@@ -0,0 +1,294 @@
+package podqos
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    PriorityRange
+		wantErr bool
+	}{
+		{value: "1000", want: PriorityRange{Min: 1000, Max: 1000}},
+		{value: "-10", want: PriorityRange{Min: -10, Max: -10}},
+		{value: "1000..2000", want: PriorityRange{Min: 1000, Max: 2000}},
+		{value: "..0", want: PriorityRange{Min: -2147483648, Max: 0}},
+		{value: "1000000000..", want: PriorityRange{Min: 1000000000, Max: 2147483647}},
+		{value: "", wantErr: true},
+		{value: "high", wantErr: true},
+		{value: "2000..1000", wantErr: true},
+		{value: "3000000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePriority(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cpu := resource.MustParse("1")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			Priority: int32Ptr(1500),
+			Containers: []corev1.Container{{
+				Name:      "web",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: cpu}},
+			}},
+		},
+	}
+	scope := func(name ensuranceapi.ScopeName, operator corev1.ScopeSelectorOperator, values ...string) ensuranceapi.ScopedResourceSelectorRequirement {
+		return ensuranceapi.ScopedResourceSelectorRequirement{ScopeName: name, Operator: operator, Values: values}
+	}
+
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		scopes  []ensuranceapi.ScopedResourceSelectorRequirement
+		want    bool
+		wantErr bool
+	}{
+		{name: "empty selectors", want: true},
+		{name: "labels", labels: map[string]string{"app": "web"}, want: true},
+		{name: "other labels", labels: map[string]string{"app": "db"}},
+		{name: "computed qos class", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.QOSClassSelector, corev1.ScopeSelectorOpIn, "Burstable"),
+		}, want: true},
+		{name: "not in namespace", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.NamespaceSelectors, corev1.ScopeSelectorOpNotIn, "kube-system", "default"),
+		}},
+		{name: "priority range", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.PrioritySelectors, corev1.ScopeSelectorOpIn, "0", "1000..2000"),
+		}, want: true},
+		{name: "priority not in range", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.PrioritySelectors, corev1.ScopeSelectorOpNotIn, "..1000"),
+		}, want: true},
+		{name: "all requirements", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.PrioritySelectors, corev1.ScopeSelectorOpIn, "1500"),
+			scope(ensuranceapi.QOSClassSelector, corev1.ScopeSelectorOpIn, "Guaranteed"),
+		}},
+		{name: "invalid priority", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.PrioritySelectors, corev1.ScopeSelectorOpIn, "high"),
+		}, wantErr: true},
+		{name: "unsupported operator", scopes: []ensuranceapi.ScopedResourceSelectorRequirement{
+			scope(ensuranceapi.NamespaceSelectors, corev1.ScopeSelectorOpExists),
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podQOS := &ensuranceapi.PodQOS{ObjectMeta: metav1.ObjectMeta{Name: "qos"}}
+			podQOS.Spec.LabelSelector.MatchLabels = tt.labels
+			if tt.scopes != nil {
+				podQOS.Spec.ScopeSelector = &ensuranceapi.ScopeSelector{MatchExpressions: tt.scopes}
+			}
+			got, err := Matches(podQOS, pod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQOSClass(t *testing.T) {
+	quantity := resource.MustParse("1")
+	resources := func(requests, limits bool) corev1.ResourceRequirements {
+		r := corev1.ResourceRequirements{}
+		if requests {
+			r.Requests = corev1.ResourceList{corev1.ResourceCPU: quantity, corev1.ResourceMemory: quantity}
+		}
+		if limits {
+			r.Limits = corev1.ResourceList{corev1.ResourceCPU: quantity, corev1.ResourceMemory: quantity}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name       string
+		containers []corev1.Container
+		status     corev1.PodQOSClass
+		want       corev1.PodQOSClass
+	}{
+		{name: "status", containers: []corev1.Container{{Resources: resources(false, false)}}, status: corev1.PodQOSGuaranteed, want: corev1.PodQOSGuaranteed},
+		{name: "no resources", containers: []corev1.Container{{Resources: resources(false, false)}}, want: corev1.PodQOSBestEffort},
+		{name: "limits only", containers: []corev1.Container{{Resources: resources(false, true)}}, want: corev1.PodQOSGuaranteed},
+		{name: "requests equal limits", containers: []corev1.Container{{Resources: resources(true, true)}}, want: corev1.PodQOSGuaranteed},
+		{name: "requests only", containers: []corev1.Container{{Resources: resources(true, false)}}, want: corev1.PodQOSBurstable},
+		{name: "one container without limits", containers: []corev1.Container{{Resources: resources(true, true)}, {}}, want: corev1.PodQOSBurstable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: tt.containers}, Status: corev1.PodStatus{QOSClass: tt.status}}
+			if got := QOSClass(pod); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	podQOS := func(name string, precedence int32, qos ensuranceapi.ResourceQOS) *ensuranceapi.PodQOS {
+		return &ensuranceapi.PodQOS{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ensuranceapi.PodQOSSpec{Precedence: precedence, ResourceQOS: qos},
+		}
+	}
+	podQOSs := []*ensuranceapi.PodQOS{
+		podQOS("default", 0, ensuranceapi.ResourceQOS{
+			CPUQOS:    &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(7), CPUBurst: ensuranceapi.CPUBurst{BurstQuota: "100"}},
+			DiskIOQOS: &ensuranceapi.DiskIOQOS{DiskIOWeight: ensuranceapi.DiskIOWeight{Weight: 10}},
+		}),
+		podQOS("batch", 0, ensuranceapi.ResourceQOS{
+			CPUQOS:    &ensuranceapi.CPUQOS{CPUBurst: ensuranceapi.CPUBurst{BurstQuota: "50"}},
+			MemoryQOS: &ensuranceapi.MemoryQOS{},
+		}),
+		podQOS("online", 10, ensuranceapi.ResourceQOS{
+			CPUQOS: &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(0)},
+		}),
+	}
+
+	merged, sources := Merge(podQOSs)
+	want := ensuranceapi.ResourceQOS{
+		CPUQOS:    &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(0), CPUBurst: ensuranceapi.CPUBurst{BurstQuota: "50"}},
+		DiskIOQOS: &ensuranceapi.DiskIOQOS{DiskIOWeight: ensuranceapi.DiskIOWeight{Weight: 10}},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("got %+v, want %+v", merged, want)
+	}
+	wantSources := Sources{
+		"cpuQOS.cpuPriority":     "online",
+		"cpuQOS.cpuBurst":        "batch",
+		"diskIOQOS.diskIOWeight": "default",
+	}
+	if !reflect.DeepEqual(sources, wantSources) {
+		t.Errorf("got sources %v, want %v", sources, wantSources)
+	}
+
+	parsed, err := ParseSources(sources.Annotation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, sources) {
+		t.Errorf("got parsed sources %v, want %v", parsed, sources)
+	}
+	if got, want := Overridden(podQOSs[0], sources), []string{"cpuQOS.cpuPriority", "cpuQOS.cpuBurst"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got overridden fields %v, want %v", got, want)
+	}
+	if *podQOSs[0].Spec.ResourceQOS.CPUQOS.CPUPriority != 7 {
+		t.Errorf("merge modified its input")
+	}
+}
+
+func TestMergeZeroValues(t *testing.T) {
+	podQOSs := []*ensuranceapi.PodQOS{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "isolated"},
+			Spec: ensuranceapi.PodQOSSpec{ResourceQOS: ensuranceapi.ResourceQOS{
+				CPUQOS: &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(7), HtIsolation: ensuranceapi.HtIsolation{Enable: true}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "online"},
+			Spec: ensuranceapi.PodQOSSpec{Precedence: 10, ResourceQOS: ensuranceapi.ResourceQOS{
+				CPUQOS: &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(0), HtIsolation: ensuranceapi.HtIsolation{Enable: false}},
+			}},
+		},
+	}
+
+	// The zero pointed CPUPriority overrides, the zero HtIsolation does not.
+	merged, sources := Merge(podQOSs)
+	want := ensuranceapi.ResourceQOS{
+		CPUQOS: &ensuranceapi.CPUQOS{CPUPriority: int32Ptr(0), HtIsolation: ensuranceapi.HtIsolation{Enable: true}},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("got %+v, want %+v", merged, want)
+	}
+	wantSources := Sources{"cpuQOS.cpuPriority": "online", "cpuQOS.htIsolation": "isolated"}
+	if !reflect.DeepEqual(sources, wantSources) {
+		t.Errorf("got sources %v, want %v", sources, wantSources)
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	var pods []ensuranceapi.PodReference
+	for i := 0; i < 12; i++ {
+		pods = append(pods, ensuranceapi.PodReference{Namespace: "default", Name: fmt.Sprintf("web-%02d", 11-i)})
+	}
+	nodes := []ensuranceapi.PodQOSNodeStatus{
+		{NodeName: "node-b", MatchedPods: 4, AppliedPods: 4, ConflictPods: 1},
+		{NodeName: "node-a", MatchedPods: 8, AppliedPods: 6},
+	}
+
+	tests := []struct {
+		name          string
+		pods          []ensuranceapi.PodReference
+		nodes         []ensuranceapi.PodQOSNodeStatus
+		wantApplied   metav1.ConditionStatus
+		wantPartially metav1.ConditionStatus
+		wantConflict  metav1.ConditionStatus
+		wantReason    string
+	}{
+		{name: "no matched pods", wantApplied: metav1.ConditionFalse, wantPartially: metav1.ConditionFalse, wantConflict: metav1.ConditionFalse, wantReason: ReasonNoMatchedPods},
+		{name: "partially applied", pods: pods, nodes: nodes, wantApplied: metav1.ConditionFalse, wantPartially: metav1.ConditionTrue, wantConflict: metav1.ConditionTrue, wantReason: ReasonSomePodsApplied},
+		{name: "applied", pods: pods[:10], nodes: nodes, wantApplied: metav1.ConditionTrue, wantPartially: metav1.ConditionFalse, wantConflict: metav1.ConditionTrue, wantReason: ReasonAllPodsApplied},
+		{name: "not applied", pods: pods, wantApplied: metav1.ConditionFalse, wantPartially: metav1.ConditionFalse, wantConflict: metav1.ConditionFalse, wantReason: ReasonNoPodsApplied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &ensuranceapi.PodQOSStatus{}
+			SetStatus(status, 3, tt.pods, tt.nodes)
+			if status.ObservedGeneration != 3 || status.MatchedPods != int32(len(tt.pods)) {
+				t.Errorf("got generation %d and %d matched pods, want 3 and %d", status.ObservedGeneration, status.MatchedPods, len(tt.pods))
+			}
+			if len(status.MatchedPodSamples) > MaxMatchedPodSamples {
+				t.Errorf("got %d samples, want at most %d", len(status.MatchedPodSamples), MaxMatchedPodSamples)
+			}
+			if len(tt.nodes) > 0 && status.Nodes[0].NodeName != "node-a" {
+				t.Errorf("got nodes %v, want them sorted by name", status.Nodes)
+			}
+			for conditionType, want := range map[ensuranceapi.PodQOSConditionType]metav1.ConditionStatus{
+				ensuranceapi.PodQOSConditionApplied:          tt.wantApplied,
+				ensuranceapi.PodQOSConditionPartiallyApplied: tt.wantPartially,
+				ensuranceapi.PodQOSConditionConflict:         tt.wantConflict,
+			} {
+				c := meta.FindStatusCondition(status.Conditions, string(conditionType))
+				if c == nil || c.Status != want {
+					t.Errorf("got condition %s %v, want %s", conditionType, c, want)
+				}
+			}
+			if c := meta.FindStatusCondition(status.Conditions, string(ensuranceapi.PodQOSConditionApplied)); c.Reason != tt.wantReason {
+				t.Errorf("got reason %s, want %s", c.Reason, tt.wantReason)
+			}
+		})
+	}
+	if pods[0].Name != "web-11" {
+		t.Errorf("SetStatus modified its input")
+	}
+}
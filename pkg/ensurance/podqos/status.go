@@ -0,0 +1,87 @@
+package podqos
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+)
+
+// MaxMatchedPodSamples is the maximum number of MatchedPodSamples in the status of a PodQOS.
+const MaxMatchedPodSamples = 10
+
+// Reasons of the conditions of PodQOS.
+const (
+	ReasonNoMatchedPods    = "NoMatchedPods"
+	ReasonAllPodsApplied   = "AllPodsApplied"
+	ReasonSomePodsApplied  = "SomePodsApplied"
+	ReasonNoPodsApplied    = "NoPodsApplied"
+	ReasonFieldsOverridden = "FieldsOverridden"
+	ReasonNoConflict       = "NoConflict"
+)
+
+// SetStatus sets status for the generation of a PodQOS from the pods it matches and the results reported
+// by the nodes running them. Matched pods on nodes that did not report are not applied.
+func SetStatus(status *ensuranceapi.PodQOSStatus, generation int64, pods []ensuranceapi.PodReference, nodes []ensuranceapi.PodQOSNodeStatus) {
+	pods = append([]ensuranceapi.PodReference(nil), pods...)
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	matched := int32(len(pods))
+	if len(pods) > MaxMatchedPodSamples {
+		pods = pods[:MaxMatchedPodSamples]
+	}
+	nodes = append([]ensuranceapi.PodQOSNodeStatus(nil), nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeName < nodes[j].NodeName })
+
+	var applied, conflicts int32
+	for _, node := range nodes {
+		applied += node.AppliedPods
+		conflicts += node.ConflictPods
+	}
+
+	status.ObservedGeneration = generation
+	status.MatchedPods = matched
+	status.MatchedPodSamples = pods
+	status.Nodes = nodes
+
+	reason := ReasonSomePodsApplied
+	switch {
+	case matched == 0:
+		reason = ReasonNoMatchedPods
+	case applied >= matched:
+		reason = ReasonAllPodsApplied
+	case applied == 0:
+		reason = ReasonNoPodsApplied
+	}
+	message := fmt.Sprintf("applied to %d of %d matched pods", applied, matched)
+	meta.SetStatusCondition(&status.Conditions, condition(ensuranceapi.PodQOSConditionApplied, reason == ReasonAllPodsApplied, reason, message, generation))
+	meta.SetStatusCondition(&status.Conditions, condition(ensuranceapi.PodQOSConditionPartiallyApplied, reason == ReasonSomePodsApplied, reason, message, generation))
+
+	conflict := condition(ensuranceapi.PodQOSConditionConflict, false, ReasonNoConflict, "no field is overridden by other PodQOS", generation)
+	if conflicts > 0 {
+		conflict = condition(ensuranceapi.PodQOSConditionConflict, true, ReasonFieldsOverridden,
+			fmt.Sprintf("fields are overridden by PodQOS of higher precedence for %d matched pods", conflicts), generation)
+	}
+	meta.SetStatusCondition(&status.Conditions, conflict)
+}
+
+func condition(conditionType ensuranceapi.PodQOSConditionType, ok bool, reason, message string, generation int64) metav1.Condition {
+	status := metav1.ConditionFalse
+	if ok {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+}
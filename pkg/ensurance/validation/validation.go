@@ -0,0 +1,231 @@
+// Package validation validates the objects of the ensurance API group.
+package validation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ensuranceapi "github.com/gocrane/api/ensurance/v1alpha1"
+	"github.com/gocrane/api/pkg/ensurance/podqos"
+)
+
+var (
+	supportedScopeNames = sets.NewString(
+		string(ensuranceapi.QOSClassSelector),
+		string(ensuranceapi.PrioritySelectors),
+		string(ensuranceapi.NamespaceSelectors),
+	)
+	supportedScopeOperators = sets.NewString(
+		string(corev1.ScopeSelectorOpIn),
+		string(corev1.ScopeSelectorOpNotIn),
+	)
+	supportedQOSClasses = sets.NewString(
+		string(corev1.PodQOSGuaranteed),
+		string(corev1.PodQOSBurstable),
+		string(corev1.PodQOSBestEffort),
+	)
+	supportedCPUSetPolicies = sets.NewString("none", "exclusive", "share")
+	supportedStrategies     = sets.NewString(
+		string(ensuranceapi.AvoidanceActionStrategyNone),
+		string(ensuranceapi.AvoidanceActionStrategyPreview),
+	)
+)
+
+// ValidatePodQOS validates a PodQOS.
+func ValidatePodQOS(podQOS *ensuranceapi.PodQOS) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&podQOS.Spec.LabelSelector, fldPath.Child("labelSelector"))...)
+	if podQOS.Spec.ScopeSelector != nil {
+		allErrs = append(allErrs, ValidateScopeSelector(podQOS.Spec.ScopeSelector, fldPath.Child("scopeSelector"))...)
+	}
+	allErrs = append(allErrs, ValidateResourceQOS(&podQOS.Spec.ResourceQOS, fldPath.Child("resourceQOS"))...)
+	if podQOS.Spec.PodQualityProbe.TimeoutSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podQualityProbe", "timeoutSeconds"), podQOS.Spec.PodQualityProbe.TimeoutSeconds, "must be greater than or equal to 0"))
+	}
+	for i, action := range podQOS.Spec.AllowedActions {
+		if action == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedActions").Index(i), ""))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateScopeSelector validates a ScopeSelector.
+func ValidateScopeSelector(selector *ensuranceapi.ScopeSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, requirement := range selector.MatchExpressions {
+		idxPath := fldPath.Child("matchExpressions").Index(i)
+		if !supportedScopeNames.Has(string(requirement.ScopeName)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("scopeName"), requirement.ScopeName, supportedScopeNames.List()))
+		}
+		if !supportedScopeOperators.Has(string(requirement.Operator)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("operator"), requirement.Operator, supportedScopeOperators.List()))
+		}
+		if len(requirement.Values) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("values"), "must be non-empty for operators In and NotIn"))
+		}
+		for j, value := range requirement.Values {
+			valuePath := idxPath.Child("values").Index(j)
+			switch requirement.ScopeName {
+			case ensuranceapi.QOSClassSelector:
+				if !supportedQOSClasses.Has(value) {
+					allErrs = append(allErrs, field.NotSupported(valuePath, value, supportedQOSClasses.List()))
+				}
+			case ensuranceapi.PrioritySelectors:
+				if _, err := podqos.ParsePriority(value); err != nil {
+					allErrs = append(allErrs, field.Invalid(valuePath, value, "must be an integer or a range of integers such as 1000..2000"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateResourceQOS validates a ResourceQOS.
+func ValidateResourceQOS(resourceQOS *ensuranceapi.ResourceQOS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cpuQOS := resourceQOS.CPUQOS; cpuQOS != nil {
+		cpuPath := fldPath.Child("cpuQOS")
+		if cpuQOS.CPUPriority != nil {
+			allErrs = append(allErrs, validateRange(int64(*cpuQOS.CPUPriority), 0, 7, cpuPath.Child("cpuPriority"))...)
+		}
+		for name, priority := range cpuQOS.ContainerPriority {
+			if priority != nil {
+				allErrs = append(allErrs, validateRange(int64(*priority), 0, 7, cpuPath.Child("containerPriority").Key(name))...)
+			}
+		}
+		if cpuQOS.CPUSet.CPUSet != "" && !supportedCPUSetPolicies.Has(cpuQOS.CPUSet.CPUSet) {
+			allErrs = append(allErrs, field.NotSupported(cpuPath.Child("cpuSet", "cpuSet"), cpuQOS.CPUSet.CPUSet, supportedCPUSetPolicies.List()))
+		}
+	}
+
+	if memoryQOS := resourceQOS.MemoryQOS; memoryQOS != nil {
+		memoryPath := fldPath.Child("memoryQOS")
+		if memoryQOS.MemoryPriority != nil {
+			allErrs = append(allErrs, validateRange(int64(*memoryQOS.MemoryPriority), 0, 7, memoryPath.Child("memPriority"))...)
+		}
+		allErrs = append(allErrs, validateRange(int64(memoryQOS.MemoryCompression.CompressionLevel), 0, 4, memoryPath.Child("memoryCompression", "compressionLevel"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateNodeQOS validates a NodeQOS.
+func ValidateNodeQOS(nodeQOS *ensuranceapi.NodeQOS) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	if nodeQOS.Spec.Selector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(nodeQOS.Spec.Selector, fldPath.Child("selector"))...)
+	}
+	if nodeQOS.Spec.NodeQualityProbe.TimeoutSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeQualityProbe", "timeoutSeconds"), nodeQOS.Spec.NodeQualityProbe.TimeoutSeconds, "must be greater than or equal to 0"))
+	}
+
+	names := sets.NewString()
+	for i := range nodeQOS.Spec.Rules {
+		idxPath := fldPath.Child("rules").Index(i)
+		rule := &nodeQOS.Spec.Rules[i]
+		if rule.Name != "" {
+			if names.Has(rule.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), rule.Name))
+			}
+			names.Insert(rule.Name)
+		}
+		allErrs = append(allErrs, ValidateRule(rule, idxPath)...)
+	}
+
+	elasticPath := fldPath.Child("elasticCpuLimit")
+	allErrs = append(allErrs, validateRange(nodeQOS.Spec.ElasticCpuLimit.ElasticNodeCpuLimit.Percent, 0, 100, elasticPath.Child("elasticNodeCpuLimit", "percent"))...)
+	for i, limit := range nodeQOS.Spec.ElasticCpuLimit.ElasticCoreCpuLimit {
+		allErrs = append(allErrs, validateRange(limit.Percent, 0, 100, elasticPath.Child("elasticCoreCpuLimit").Index(i).Child("percent"))...)
+	}
+	for i, limit := range nodeQOS.Spec.ElasticCpuLimit.ElasticCoreCpuLimitPeriod {
+		allErrs = append(allErrs, validateRange(limit.Percent, 0, 100, elasticPath.Child("elasticCoreCpuLimitPeriod").Index(i).Child("percent"))...)
+	}
+
+	netPath := fldPath.Child("netLimits")
+	netLimits := nodeQOS.Spec.NetLimits
+	if netLimits.RXBpsMin != nil && netLimits.RXBpsMax != nil && *netLimits.RXBpsMin > *netLimits.RXBpsMax {
+		allErrs = append(allErrs, field.Invalid(netPath.Child("rxBpsMin"), *netLimits.RXBpsMin, "must be less than or equal to rxBpsMax"))
+	}
+	if netLimits.TXBpsMin != nil && netLimits.TXBpsMax != nil && *netLimits.TXBpsMin > *netLimits.TXBpsMax {
+		allErrs = append(allErrs, field.Invalid(netPath.Child("txBpsMin"), *netLimits.TXBpsMin, "must be less than or equal to txBpsMax"))
+	}
+
+	return allErrs
+}
+
+// ValidateRule validates a Rule. Zero thresholds are accepted as unset and default to 1.
+func ValidateRule(rule *ensuranceapi.Rule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rule.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	if rule.MetricRule == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("metricRule"), ""))
+	} else {
+		if rule.MetricRule.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("metricRule", "name"), ""))
+		}
+		if rule.MetricRule.Selector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(rule.MetricRule.Selector, fldPath.Child("metricRule", "selector"))...)
+		}
+	}
+	if rule.AvoidanceThreshold < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("avoidanceThreshold"), rule.AvoidanceThreshold, "must be greater than or equal to 0"))
+	}
+	if rule.RestoreThreshold < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("restoreThreshold"), rule.RestoreThreshold, "must be greater than or equal to 0"))
+	}
+	if rule.AvoidanceActionName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("actionName"), ""))
+	}
+	if rule.Strategy != "" && !supportedStrategies.Has(string(rule.Strategy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy"), rule.Strategy, supportedStrategies.List()))
+	}
+
+	return allErrs
+}
+
+// ValidateAvoidanceAction validates an AvoidanceAction. A zero CoolDownSeconds is accepted as unset and defaults to 300.
+func ValidateAvoidanceAction(action *ensuranceapi.AvoidanceAction) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec")
+	if action.Spec.CoolDownSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("coolDownSeconds"), action.Spec.CoolDownSeconds, "must be greater than or equal to 0"))
+	}
+	if throttle := action.Spec.Throttle; throttle != nil {
+		throttlePath := fldPath.Child("throttle", "cpuThrottle")
+		allErrs = append(allErrs, validateRange(int64(throttle.CPUThrottle.MinCPURatio), 0, 100, throttlePath.Child("minCPURatio"))...)
+		allErrs = append(allErrs, validateRange(int64(throttle.CPUThrottle.StepCPURatio), 0, 100, throttlePath.Child("stepCPURatio"))...)
+	}
+	if eviction := action.Spec.Eviction; eviction != nil && eviction.TerminationGracePeriodSeconds != nil && *eviction.TerminationGracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("eviction", "terminationGracePeriodSeconds"), *eviction.TerminationGracePeriodSeconds, "must be greater than or equal to 0"))
+	}
+	if len(action.Spec.Description) > 1024 {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("description"), action.Spec.Description, 1024))
+	}
+
+	return allErrs
+}
+
+func validateRange(value, min, max int64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value < min || value > max {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be between %d and %d inclusive", min, max)))
+	}
+	return allErrs
+}
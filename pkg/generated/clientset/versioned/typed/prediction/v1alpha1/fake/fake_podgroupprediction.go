@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePodGroupPredictions implements PodGroupPredictionInterface
+type FakePodGroupPredictions struct {
+	Fake *FakePredictionV1alpha1
+	ns   string
+}
+
+var podgrouppredictionsResource = schema.GroupVersionResource{Group: "prediction.crane.io", Version: "v1alpha1", Resource: "podgrouppredictions"}
+
+var podgrouppredictionsKind = schema.GroupVersionKind{Group: "prediction.crane.io", Version: "v1alpha1", Kind: "PodGroupPrediction"}
+
+// Get takes name of the podGroupPrediction, and returns the corresponding podGroupPrediction object, and an error if there is any.
+func (c *FakePodGroupPredictions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(podgrouppredictionsResource, c.ns, name), &v1alpha1.PodGroupPrediction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), err
+}
+
+// List takes label and field selectors, and returns the list of PodGroupPredictions that match those selectors.
+func (c *FakePodGroupPredictions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PodGroupPredictionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(podgrouppredictionsResource, podgrouppredictionsKind, c.ns, opts), &v1alpha1.PodGroupPredictionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.PodGroupPredictionList{ListMeta: obj.(*v1alpha1.PodGroupPredictionList).ListMeta}
+	for _, item := range obj.(*v1alpha1.PodGroupPredictionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested podGroupPredictions.
+func (c *FakePodGroupPredictions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(podgrouppredictionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a podGroupPrediction and creates it.  Returns the server's representation of the podGroupPrediction, and an error, if there is any.
+func (c *FakePodGroupPredictions) Create(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.CreateOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(podgrouppredictionsResource, c.ns, podGroupPrediction), &v1alpha1.PodGroupPrediction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), err
+}
+
+// Update takes the representation of a podGroupPrediction and updates it. Returns the server's representation of the podGroupPrediction, and an error, if there is any.
+func (c *FakePodGroupPredictions) Update(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(podgrouppredictionsResource, c.ns, podGroupPrediction), &v1alpha1.PodGroupPrediction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePodGroupPredictions) UpdateStatus(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (*v1alpha1.PodGroupPrediction, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(podgrouppredictionsResource, "status", c.ns, podGroupPrediction), &v1alpha1.PodGroupPrediction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), err
+}
+
+// Delete takes name of the podGroupPrediction and deletes it. Returns an error if one occurs.
+func (c *FakePodGroupPredictions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(podgrouppredictionsResource, c.ns, name), &v1alpha1.PodGroupPrediction{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePodGroupPredictions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(podgrouppredictionsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.PodGroupPredictionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched podGroupPrediction.
+func (c *FakePodGroupPredictions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PodGroupPrediction, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(podgrouppredictionsResource, c.ns, name, pt, data, subresources...), &v1alpha1.PodGroupPrediction{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), err
+}
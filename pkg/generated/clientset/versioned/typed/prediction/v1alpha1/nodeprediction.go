@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/gocrane/api/pkg/generated/clientset/versioned/scheme"
+	v1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NodePredictionsGetter has a method to return a NodePredictionInterface.
+// A group's client should implement this interface.
+type NodePredictionsGetter interface {
+	NodePredictions() NodePredictionInterface
+}
+
+// NodePredictionInterface has methods to work with NodePrediction resources.
+type NodePredictionInterface interface {
+	Create(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.CreateOptions) (*v1alpha1.NodePrediction, error)
+	Update(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.UpdateOptions) (*v1alpha1.NodePrediction, error)
+	UpdateStatus(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.UpdateOptions) (*v1alpha1.NodePrediction, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NodePrediction, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NodePredictionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NodePrediction, err error)
+	NodePredictionExpansion
+}
+
+// nodePredictions implements NodePredictionInterface
+type nodePredictions struct {
+	client rest.Interface
+}
+
+// newNodePredictions returns a NodePredictions
+func newNodePredictions(c *PredictionV1alpha1Client) *nodePredictions {
+	return &nodePredictions{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the nodePrediction, and returns the corresponding nodePrediction object, and an error if there is any.
+func (c *nodePredictions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.NodePrediction, err error) {
+	result = &v1alpha1.NodePrediction{}
+	err = c.client.Get().
+		Resource("nodepredictions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NodePredictions that match those selectors.
+func (c *nodePredictions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.NodePredictionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.NodePredictionList{}
+	err = c.client.Get().
+		Resource("nodepredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested nodePredictions.
+func (c *nodePredictions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("nodepredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a nodePrediction and creates it.  Returns the server's representation of the nodePrediction, and an error, if there is any.
+func (c *nodePredictions) Create(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.CreateOptions) (result *v1alpha1.NodePrediction, err error) {
+	result = &v1alpha1.NodePrediction{}
+	err = c.client.Post().
+		Resource("nodepredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodePrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a nodePrediction and updates it. Returns the server's representation of the nodePrediction, and an error, if there is any.
+func (c *nodePredictions) Update(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.UpdateOptions) (result *v1alpha1.NodePrediction, err error) {
+	result = &v1alpha1.NodePrediction{}
+	err = c.client.Put().
+		Resource("nodepredictions").
+		Name(nodePrediction.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodePrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *nodePredictions) UpdateStatus(ctx context.Context, nodePrediction *v1alpha1.NodePrediction, opts v1.UpdateOptions) (result *v1alpha1.NodePrediction, err error) {
+	result = &v1alpha1.NodePrediction{}
+	err = c.client.Put().
+		Resource("nodepredictions").
+		Name(nodePrediction.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(nodePrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the nodePrediction and deletes it. Returns an error if one occurs.
+func (c *nodePredictions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("nodepredictions").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *nodePredictions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("nodepredictions").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched nodePrediction.
+func (c *nodePredictions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NodePrediction, err error) {
+	result = &v1alpha1.NodePrediction{}
+	err = c.client.Patch(pt).
+		Resource("nodepredictions").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/gocrane/api/pkg/generated/clientset/versioned/scheme"
+	v1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PodGroupPredictionsGetter has a method to return a PodGroupPredictionInterface.
+// A group's client should implement this interface.
+type PodGroupPredictionsGetter interface {
+	PodGroupPredictions(namespace string) PodGroupPredictionInterface
+}
+
+// PodGroupPredictionInterface has methods to work with PodGroupPrediction resources.
+type PodGroupPredictionInterface interface {
+	Create(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.CreateOptions) (*v1alpha1.PodGroupPrediction, error)
+	Update(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (*v1alpha1.PodGroupPrediction, error)
+	UpdateStatus(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (*v1alpha1.PodGroupPrediction, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.PodGroupPrediction, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PodGroupPredictionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PodGroupPrediction, err error)
+	PodGroupPredictionExpansion
+}
+
+// podGroupPredictions implements PodGroupPredictionInterface
+type podGroupPredictions struct {
+	client rest.Interface
+	ns     string
+}
+
+// newPodGroupPredictions returns a PodGroupPredictions
+func newPodGroupPredictions(c *PredictionV1alpha1Client, namespace string) *podGroupPredictions {
+	return &podGroupPredictions{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the podGroupPrediction, and returns the corresponding podGroupPrediction object, and an error if there is any.
+func (c *podGroupPredictions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	result = &v1alpha1.PodGroupPrediction{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of PodGroupPredictions that match those selectors.
+func (c *podGroupPredictions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.PodGroupPredictionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.PodGroupPredictionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested podGroupPredictions.
+func (c *podGroupPredictions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a podGroupPrediction and creates it.  Returns the server's representation of the podGroupPrediction, and an error, if there is any.
+func (c *podGroupPredictions) Create(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.CreateOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	result = &v1alpha1.PodGroupPrediction{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroupPrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a podGroupPrediction and updates it. Returns the server's representation of the podGroupPrediction, and an error, if there is any.
+func (c *podGroupPredictions) Update(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	result = &v1alpha1.PodGroupPrediction{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		Name(podGroupPrediction.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroupPrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *podGroupPredictions) UpdateStatus(ctx context.Context, podGroupPrediction *v1alpha1.PodGroupPrediction, opts v1.UpdateOptions) (result *v1alpha1.PodGroupPrediction, err error) {
+	result = &v1alpha1.PodGroupPrediction{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		Name(podGroupPrediction.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(podGroupPrediction).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the podGroupPrediction and deletes it. Returns an error if one occurs.
+func (c *podGroupPredictions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *podGroupPredictions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched podGroupPrediction.
+func (c *podGroupPredictions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PodGroupPrediction, err error) {
+	result = &v1alpha1.PodGroupPrediction{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("podgrouppredictions").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
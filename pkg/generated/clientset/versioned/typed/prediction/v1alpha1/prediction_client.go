@@ -11,6 +11,8 @@ import (
 type PredictionV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterNodePredictionsGetter
+	NodePredictionsGetter
+	PodGroupPredictionsGetter
 	TimeSeriesPredictionsGetter
 }
 
@@ -23,6 +25,14 @@ func (c *PredictionV1alpha1Client) ClusterNodePredictions(namespace string) Clus
 	return newClusterNodePredictions(c, namespace)
 }
 
+func (c *PredictionV1alpha1Client) NodePredictions() NodePredictionInterface {
+	return newNodePredictions(c)
+}
+
+func (c *PredictionV1alpha1Client) PodGroupPredictions(namespace string) PodGroupPredictionInterface {
+	return newPodGroupPredictions(c, namespace)
+}
+
 func (c *PredictionV1alpha1Client) TimeSeriesPredictions(namespace string) TimeSeriesPredictionInterface {
 	return newTimeSeriesPredictions(c, namespace)
 }
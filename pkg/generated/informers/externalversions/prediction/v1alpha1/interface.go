@@ -10,6 +10,10 @@ import (
 type Interface interface {
 	// ClusterNodePredictions returns a ClusterNodePredictionInformer.
 	ClusterNodePredictions() ClusterNodePredictionInformer
+	// NodePredictions returns a NodePredictionInformer.
+	NodePredictions() NodePredictionInformer
+	// PodGroupPredictions returns a PodGroupPredictionInformer.
+	PodGroupPredictions() PodGroupPredictionInformer
 	// TimeSeriesPredictions returns a TimeSeriesPredictionInformer.
 	TimeSeriesPredictions() TimeSeriesPredictionInformer
 }
@@ -30,6 +34,16 @@ func (v *version) ClusterNodePredictions() ClusterNodePredictionInformer {
 	return &clusterNodePredictionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// NodePredictions returns a NodePredictionInformer.
+func (v *version) NodePredictions() NodePredictionInformer {
+	return &nodePredictionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PodGroupPredictions returns a PodGroupPredictionInformer.
+func (v *version) PodGroupPredictions() PodGroupPredictionInformer {
+	return &podGroupPredictionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // TimeSeriesPredictions returns a TimeSeriesPredictionInformer.
 func (v *version) TimeSeriesPredictions() TimeSeriesPredictionInformer {
 	return &timeSeriesPredictionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
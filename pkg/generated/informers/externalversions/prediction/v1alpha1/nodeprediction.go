@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	versioned "github.com/gocrane/api/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/gocrane/api/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/gocrane/api/pkg/generated/listers/prediction/v1alpha1"
+	predictionv1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NodePredictionInformer provides access to a shared informer and lister for
+// NodePredictions.
+type NodePredictionInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.NodePredictionLister
+}
+
+type nodePredictionInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewNodePredictionInformer constructs a new informer for NodePrediction type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNodePredictionInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNodePredictionInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNodePredictionInformer constructs a new informer for NodePrediction type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNodePredictionInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PredictionV1alpha1().NodePredictions().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.PredictionV1alpha1().NodePredictions().Watch(context.TODO(), options)
+			},
+		},
+		&predictionv1alpha1.NodePrediction{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *nodePredictionInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNodePredictionInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *nodePredictionInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&predictionv1alpha1.NodePrediction{}, f.defaultInformer)
+}
+
+func (f *nodePredictionInformer) Lister() v1alpha1.NodePredictionLister {
+	return v1alpha1.NewNodePredictionLister(f.Informer().GetIndexer())
+}
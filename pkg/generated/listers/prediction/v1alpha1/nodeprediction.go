@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NodePredictionLister helps list NodePredictions.
+// All objects returned here must be treated as read-only.
+type NodePredictionLister interface {
+	// List lists all NodePredictions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.NodePrediction, err error)
+	// Get retrieves the NodePrediction from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.NodePrediction, error)
+	NodePredictionListerExpansion
+}
+
+// nodePredictionLister implements the NodePredictionLister interface.
+type nodePredictionLister struct {
+	indexer cache.Indexer
+}
+
+// NewNodePredictionLister returns a new NodePredictionLister.
+func NewNodePredictionLister(indexer cache.Indexer) NodePredictionLister {
+	return &nodePredictionLister{indexer: indexer}
+}
+
+// List lists all NodePredictions in the indexer.
+func (s *nodePredictionLister) List(selector labels.Selector) (ret []*v1alpha1.NodePrediction, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.NodePrediction))
+	})
+	return ret, err
+}
+
+// Get retrieves the NodePrediction from the index for a given name.
+func (s *nodePredictionLister) Get(name string) (*v1alpha1.NodePrediction, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("nodeprediction"), name)
+	}
+	return obj.(*v1alpha1.NodePrediction), nil
+}
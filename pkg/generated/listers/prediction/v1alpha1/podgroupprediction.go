@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gocrane/api/prediction/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PodGroupPredictionLister helps list PodGroupPredictions.
+// All objects returned here must be treated as read-only.
+type PodGroupPredictionLister interface {
+	// List lists all PodGroupPredictions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PodGroupPrediction, err error)
+	// PodGroupPredictions returns an object that can list and get PodGroupPredictions.
+	PodGroupPredictions(namespace string) PodGroupPredictionNamespaceLister
+	PodGroupPredictionListerExpansion
+}
+
+// podGroupPredictionLister implements the PodGroupPredictionLister interface.
+type podGroupPredictionLister struct {
+	indexer cache.Indexer
+}
+
+// NewPodGroupPredictionLister returns a new PodGroupPredictionLister.
+func NewPodGroupPredictionLister(indexer cache.Indexer) PodGroupPredictionLister {
+	return &podGroupPredictionLister{indexer: indexer}
+}
+
+// List lists all PodGroupPredictions in the indexer.
+func (s *podGroupPredictionLister) List(selector labels.Selector) (ret []*v1alpha1.PodGroupPrediction, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PodGroupPrediction))
+	})
+	return ret, err
+}
+
+// PodGroupPredictions returns an object that can list and get PodGroupPredictions.
+func (s *podGroupPredictionLister) PodGroupPredictions(namespace string) PodGroupPredictionNamespaceLister {
+	return podGroupPredictionNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PodGroupPredictionNamespaceLister helps list and get PodGroupPredictions.
+// All objects returned here must be treated as read-only.
+type PodGroupPredictionNamespaceLister interface {
+	// List lists all PodGroupPredictions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.PodGroupPrediction, err error)
+	// Get retrieves the PodGroupPrediction from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.PodGroupPrediction, error)
+	PodGroupPredictionNamespaceListerExpansion
+}
+
+// podGroupPredictionNamespaceLister implements the PodGroupPredictionNamespaceLister
+// interface.
+type podGroupPredictionNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all PodGroupPredictions in the indexer for a given namespace.
+func (s podGroupPredictionNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.PodGroupPrediction, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PodGroupPrediction))
+	})
+	return ret, err
+}
+
+// Get retrieves the PodGroupPrediction from the indexer for a given namespace and name.
+func (s podGroupPredictionNamespaceLister) Get(name string) (*v1alpha1.PodGroupPrediction, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("podgroupprediction"), name)
+	}
+	return obj.(*v1alpha1.PodGroupPrediction), nil
+}
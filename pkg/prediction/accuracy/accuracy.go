@@ -0,0 +1,169 @@
+// Package accuracy tracks how close the past predictions of a TimeSeriesPrediction were to the
+// actual samples of their metrics, so that consumers such as EHPA can stop relying on a poor
+// prediction.
+//
+// Predicted and actual series are matched by their labels. Predicted samples sit on the grid of
+// the algorithm while actual samples sit on the scrape or query step, so every predicted sample
+// is compared with the actual series interpolated linearly at its timestamp. NaN samples are
+// left out.
+package accuracy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// DefaultWindowSeconds is the rolling window of an AccuracyPolicy without WindowSeconds.
+const DefaultWindowSeconds = 86400
+
+// Reasons of the AccuracyDegraded condition.
+const (
+	ReasonAccuracyDegraded = "AccuracyDegraded"
+	ReasonAccuracyAccepted = "AccuracyAccepted"
+)
+
+// Window returns the rolling window [start, end) of policy ending at now, in seconds since the epoch.
+func Window(policy *predictionapi.AccuracyPolicy, now time.Time) (start, end int64) {
+	window := int64(DefaultWindowSeconds)
+	if policy != nil && policy.WindowSeconds > 0 {
+		window = int64(policy.WindowSeconds)
+	}
+	end = now.Unix()
+	return end - window, end
+}
+
+// Evaluate compares the predicted samples with the actual samples in the window of policy ending
+// at now, and checks the errors against the thresholds of policy.
+func Evaluate(predicted, actual predictionapi.MetricTimeSeriesList, policy *predictionapi.AccuracyPolicy, now time.Time) (*predictionapi.PredictionAccuracy, error) {
+	start, end := Window(policy, now)
+	accuracy, err := Compare(predicted, actual, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if accuracy.Degraded, err = Degraded(accuracy, policy); err != nil {
+		return nil, err
+	}
+	return accuracy, nil
+}
+
+// Compare computes the MAPE and RMSE of the predicted samples in the window [start, end) against
+// the actual series interpolated at their timestamps. The errors are left empty when no sample can be compared.
+func Compare(predicted, actual predictionapi.MetricTimeSeriesList, start, end int64) (*predictionapi.PredictionAccuracy, error) {
+	predictedSeries, err := timeseries.FromMetricTimeSeriesList(predicted)
+	if err != nil {
+		return nil, fmt.Errorf("predicted: %w", err)
+	}
+	actualSeries, err := timeseries.FromMetricTimeSeriesList(actual)
+	if err != nil {
+		return nil, fmt.Errorf("actual: %w", err)
+	}
+	actualByLabels := make(map[string]*timeseries.Series, len(actualSeries))
+	for _, s := range actualSeries {
+		actualByLabels[timeseries.LabelsKey(s.Labels)] = s
+	}
+
+	var samples, percentageSamples int
+	var squaredErrors, percentageErrors float64
+	for _, p := range predictedSeries {
+		a, ok := actualByLabels[timeseries.LabelsKey(p.Labels)]
+		if !ok {
+			continue
+		}
+		for _, point := range p.Points {
+			if point.Timestamp < start || point.Timestamp >= end || math.IsNaN(point.Value) {
+				continue
+			}
+			value, ok := a.Interpolate(point.Timestamp)
+			if !ok {
+				continue
+			}
+			diff := point.Value - value
+			samples++
+			squaredErrors += diff * diff
+			if value != 0 {
+				percentageSamples++
+				percentageErrors += math.Abs(diff / value)
+			}
+		}
+	}
+
+	accuracy := &predictionapi.PredictionAccuracy{Samples: int32(samples), WindowStart: start, WindowEnd: end}
+	if samples > 0 {
+		accuracy.RMSE = timeseries.FormatValue(math.Sqrt(squaredErrors / float64(samples)))
+	}
+	if percentageSamples > 0 {
+		accuracy.MAPE = timeseries.FormatValue(percentageErrors / float64(percentageSamples))
+	}
+	return accuracy, nil
+}
+
+// Degraded reports whether an error of accuracy exceeds a threshold of policy. An error or a
+// threshold which is not set is not checked.
+func Degraded(accuracy *predictionapi.PredictionAccuracy, policy *predictionapi.AccuracyPolicy) (bool, error) {
+	if policy == nil {
+		return false, nil
+	}
+	mape, err := exceeds(accuracy.MAPE, policy.MAPEThreshold)
+	if err != nil {
+		return false, fmt.Errorf("mape: %w", err)
+	}
+	rmse, err := exceeds(accuracy.RMSE, policy.RMSEThreshold)
+	if err != nil {
+		return false, fmt.Errorf("rmse: %w", err)
+	}
+	return mape || rmse, nil
+}
+
+func exceeds(value, threshold string) (bool, error) {
+	if value == "" || threshold == "" {
+		return false, nil
+	}
+	v, err := timeseries.ParseValue(value)
+	if err != nil {
+		return false, err
+	}
+	t, err := timeseries.ParseValue(threshold)
+	if err != nil {
+		return false, err
+	}
+	return v > t, nil
+}
+
+// Condition returns the AccuracyDegraded condition of a TimeSeriesPrediction with the metric
+// statuses, true when the accuracy of any metric is degraded.
+func Condition(statuses []predictionapi.PredictionMetricStatus, observedGeneration int64) metav1.Condition {
+	var degraded []string
+	for _, status := range statuses {
+		if status.Accuracy != nil && status.Accuracy.Degraded {
+			degraded = append(degraded, status.ResourceIdentifier)
+		}
+	}
+	condition := metav1.Condition{
+		Type:               string(predictionapi.TimeSeriesPredictionConditionAccuracyDegraded),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: observedGeneration,
+		Reason:             ReasonAccuracyAccepted,
+		Message:            "the errors of all metrics are within the thresholds",
+	}
+	if len(degraded) > 0 {
+		sort.Strings(degraded)
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = ReasonAccuracyDegraded
+		condition.Message = "the errors of metrics " + strings.Join(degraded, ", ") + " exceed the thresholds"
+	}
+	return condition
+}
+
+// IsDegraded reports whether the AccuracyDegraded condition of tsp is true.
+func IsDegraded(tsp *predictionapi.TimeSeriesPrediction) bool {
+	return meta.IsStatusConditionTrue(tsp.Status.Conditions, string(predictionapi.TimeSeriesPredictionConditionAccuracyDegraded))
+}
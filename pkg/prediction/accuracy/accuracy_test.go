@@ -0,0 +1,92 @@
+package accuracy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func TestEvaluate(t *testing.T) {
+	web0 := []predictionapi.Label{{Name: "pod", Value: "web-0"}}
+	web1 := []predictionapi.Label{{Name: "pod", Value: "web-1"}}
+	predicted := predictionapi.MetricTimeSeriesList{
+		{Labels: web0, Samples: []predictionapi.Sample{{Timestamp: 0, Value: "100"}, {Timestamp: 60, Value: "12"}, {Timestamp: 120, Value: "6"}, {Timestamp: 180, Value: "5"}}},
+		{Labels: web1, Samples: []predictionapi.Sample{{Timestamp: 60, Value: "1"}}},
+	}
+	actual := predictionapi.MetricTimeSeriesList{
+		{Labels: web0, Samples: []predictionapi.Sample{{Timestamp: 0, Value: "1"}, {Timestamp: 60, Value: "10"}, {Timestamp: 120, Value: "0"}, {Timestamp: 180, Value: "NaN"}}},
+	}
+	policy := &predictionapi.AccuracyPolicy{WindowSeconds: 180, MAPEThreshold: "0.1"}
+
+	got, err := Evaluate(predicted, actual, policy, time.Unix(240, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compared are 12 with 10 and 6 with 0, which is left out of the MAPE.
+	want := &predictionapi.PredictionAccuracy{MAPE: "0.2", RMSE: "4.47213595499958", Samples: 2, WindowStart: 60, WindowEnd: 240, Degraded: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	policy.MAPEThreshold = ""
+	policy.RMSEThreshold = "5"
+	if got, _ := Evaluate(predicted, actual, policy, time.Unix(240, 0)); got.Degraded {
+		t.Error("got degraded, want within the rmse threshold")
+	}
+
+	none, err := Compare(predicted, nil, 0, 240)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if none.Samples != 0 || none.MAPE != "" || none.RMSE != "" {
+		t.Errorf("got %+v without actual samples, want no errors", none)
+	}
+}
+
+func TestCompareOffset(t *testing.T) {
+	// Predicted every minute on the grid of the algorithm, actual scraped every 30s with an offset of 15s.
+	predicted := predictionapi.MetricTimeSeriesList{
+		{Samples: []predictionapi.Sample{{Timestamp: 60, Value: "10"}, {Timestamp: 120, Value: "20"}, {Timestamp: 180, Value: "40"}}},
+	}
+	actual := predictionapi.MetricTimeSeriesList{
+		{Samples: []predictionapi.Sample{{Timestamp: 45, Value: "8"}, {Timestamp: 75, Value: "12"}, {Timestamp: 105, Value: "20"}, {Timestamp: 135, Value: "20"}, {Timestamp: 165, Value: "30"}}},
+	}
+
+	got, err := Compare(predicted, actual, 0, 240)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compared are 10 with 10 and 20 with 20, interpolated, and 40 is after the last actual sample.
+	want := &predictionapi.PredictionAccuracy{MAPE: "0", RMSE: "0", Samples: 2, WindowStart: 0, WindowEnd: 240}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCondition(t *testing.T) {
+	statuses := []predictionapi.PredictionMetricStatus{
+		{ResourceIdentifier: "memory", Accuracy: &predictionapi.PredictionAccuracy{Degraded: true}},
+		{ResourceIdentifier: "cpu", Accuracy: &predictionapi.PredictionAccuracy{Degraded: true}},
+		{ResourceIdentifier: "qps"},
+	}
+	condition := Condition(statuses, 3)
+	if condition.Status != metav1.ConditionTrue || condition.Reason != ReasonAccuracyDegraded || condition.ObservedGeneration != 3 {
+		t.Errorf("got %+v, want a true condition", condition)
+	}
+	if want := "the errors of metrics cpu, memory exceed the thresholds"; condition.Message != want {
+		t.Errorf("got message %q, want %q", condition.Message, want)
+	}
+
+	tsp := &predictionapi.TimeSeriesPrediction{}
+	tsp.Status.Conditions = []metav1.Condition{condition}
+	if !IsDegraded(tsp) {
+		t.Error("got not degraded, want degraded")
+	}
+	if condition := Condition(statuses[2:], 3); condition.Status != metav1.ConditionFalse {
+		t.Errorf("got %+v, want a false condition", condition)
+	}
+}
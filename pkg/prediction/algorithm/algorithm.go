@@ -0,0 +1,133 @@
+// Package algorithm holds reference implementations of the prediction algorithms configured by
+// predictionapi.Algorithm, so that predictions can be reproduced offline from the history of a
+// metric and the effect of a config change can be checked before it is rolled out.
+//
+// The history is first resampled to the sample interval of the config, averaging the samples of
+// an interval, and its gaps are filled linearly. The predicted samples follow the last sample of
+// the history at the same interval, over the prediction window.
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	"github.com/gocrane/api/pkg/util/duration"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var (
+	// ErrUnsupportedAlgorithm is returned for an algorithm without a reference implementation.
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	// ErrInsufficientSamples is returned when the history is too short for the algorithm.
+	ErrInsufficientSamples = errors.New("insufficient samples")
+	// ErrNotPeriodic is returned by DSP when the history has no period.
+	ErrNotPeriodic = errors.New("time series is not periodic")
+)
+
+// Prediction is the result of an algorithm.
+type Prediction struct {
+	// Samples are the predicted samples.
+	Samples []predictionapi.Sample
+	// UpperBound and LowerBound are the bounds of the predicted samples, set when the config
+	// of the algorithm requests Bounds.
+	UpperBound []predictionapi.Sample
+	LowerBound []predictionapi.Sample
+}
+
+// Predict predicts the samples following samples over window with algorithm.
+func Predict(samples []predictionapi.Sample, algorithm *predictionapi.Algorithm, window time.Duration) (*Prediction, error) {
+	switch algorithm.AlgorithmType {
+	case predictionapi.AlgorithmTypeDSP:
+		config := algorithm.DSP
+		if config == nil {
+			config = &predictionapi.DSP{}
+		}
+		return PredictDSP(samples, config, window)
+	case predictionapi.AlgorithmTypePercentile:
+		config := algorithm.Percentile
+		if config == nil {
+			config = &predictionapi.Percentile{}
+		}
+		return PredictPercentile(samples, config, window)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm.AlgorithmType)
+	}
+}
+
+// history parses samples, resamples them to interval, fills their gaps and keeps the last
+// historyLength of them.
+func history(samples []predictionapi.Sample, interval, historyLength time.Duration) (*timeseries.Series, error) {
+	s, err := timeseries.FromMetricTimeSeries(&predictionapi.MetricTimeSeries{Samples: samples})
+	if err != nil {
+		return nil, err
+	}
+	s = timeseries.FillGaps(timeseries.Resample(s, interval, timeseries.Avg), interval, timeseries.FillLinear)
+
+	// Missing values before the first or after the last present value cannot be filled.
+	points := s.Points
+	for len(points) > 0 && math.IsNaN(points[0].Value) {
+		points = points[1:]
+	}
+	for len(points) > 0 && math.IsNaN(points[len(points)-1].Value) {
+		points = points[:len(points)-1]
+	}
+	if n := int(historyLength / interval); len(points) > n {
+		points = points[len(points)-n:]
+	}
+	s.Points = points
+	return s, nil
+}
+
+// project returns the n samples following the timestamp last every interval, with the values of value.
+func project(last int64, interval time.Duration, n int, value func(i int) float64) []predictionapi.Sample {
+	seconds := int64(interval / time.Second)
+	samples := make([]predictionapi.Sample, n)
+	for i := range samples {
+		samples[i] = predictionapi.Sample{Timestamp: last + int64(i+1)*seconds, Value: timeseries.FormatValue(value(i))}
+	}
+	return samples
+}
+
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return duration.Parse(value)
+}
+
+// parseInterval parses a sample interval, which must be at least one second as samples are timestamped in seconds.
+func parseInterval(value string, defaultValue time.Duration) (time.Duration, error) {
+	interval, err := parseDuration(value, defaultValue)
+	if err != nil {
+		return 0, err
+	}
+	if interval < time.Second {
+		return 0, fmt.Errorf("%v is less than 1s", interval)
+	}
+	return interval, nil
+}
+
+func parseFloat(value string, defaultValue float64) (float64, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return timeseries.ParseValue(value)
+}
+
+// confidenceLevel returns the confidence level of bounds, 0 without bounds.
+func confidenceLevel(bounds *predictionapi.Bounds) (float64, error) {
+	if bounds == nil {
+		return 0, nil
+	}
+	level, err := timeseries.ParseValue(bounds.ConfidenceLevel)
+	if err != nil {
+		return 0, fmt.Errorf("confidence level: %w", err)
+	}
+	if level <= 0 || level >= 1 {
+		return 0, fmt.Errorf("confidence level %v is not between 0 and 1 exclusive", level)
+	}
+	return level, nil
+}
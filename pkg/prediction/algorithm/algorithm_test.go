@@ -0,0 +1,204 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// daily returns days of samples every minute of a daily sine wave around 10 with an amplitude of 5.
+func daily(days int, noise float64) []predictionapi.Sample {
+	r := rand.New(rand.NewSource(1))
+	var samples []predictionapi.Sample
+	for t := int64(0); t < int64(days)*86400; t += 60 {
+		v := 10 + 5*math.Sin(2*math.Pi*float64(t)/86400) + noise*r.NormFloat64()
+		samples = append(samples, predictionapi.Sample{Timestamp: t, Value: timeseries.FormatValue(v)})
+	}
+	return samples
+}
+
+func values(t *testing.T, samples []predictionapi.Sample) []float64 {
+	t.Helper()
+	v, err := timeseries.Floats(&predictionapi.MetricTimeSeries{Samples: samples})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestFFT(t *testing.T) {
+	for _, n := range []int{1, 8, 12, 17} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(float64(i*i%7), float64(i%3))
+		}
+		got := fft(x)
+		for k := range x {
+			var want complex128
+			for j := range x {
+				want += x[j] * cmplx.Rect(1, -2*math.Pi*float64(j*k)/float64(n))
+			}
+			if cmplx.Abs(got[k]-want) > 1e-9 {
+				t.Errorf("n=%d: got X[%d] = %v, want %v", n, k, got[k], want)
+			}
+		}
+		for i, v := range ifft(got) {
+			if cmplx.Abs(v-x[i]) > 1e-9 {
+				t.Errorf("n=%d: got x[%d] = %v after a round trip, want %v", n, i, v, x[i])
+			}
+		}
+	}
+}
+
+func TestPredictDSP(t *testing.T) {
+	samples := daily(3, 0.1)
+	tests := []struct {
+		name      string
+		config    predictionapi.DSP
+		tolerance float64
+	}{
+		{name: "default estimators", tolerance: 0.5},
+		{
+			name: "fft",
+			config: predictionapi.DSP{Estimators: predictionapi.Estimators{
+				FFTEstimators: []*predictionapi.FFTEstimator{{LowAmplitudeThreshold: "1"}},
+			}},
+			tolerance: 0.1,
+		},
+		{
+			name: "max value with margin",
+			config: predictionapi.DSP{Estimators: predictionapi.Estimators{
+				MaxValueEstimators: []*predictionapi.MaxValueEstimator{{MarginFraction: "0.1"}},
+			}},
+			// The maximum of the noise is above the wave, plus 10% of up to 15.
+			tolerance: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prediction, err := PredictDSP(samples, &tt.config, 12*time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(prediction.Samples) != 720 || prediction.Samples[0].Timestamp != 3*86400 {
+				t.Fatalf("got %d samples from %d, want 720 from %d", len(prediction.Samples), prediction.Samples[0].Timestamp, 3*86400)
+			}
+			for i, v := range values(t, prediction.Samples) {
+				want := 10 + 5*math.Sin(2*math.Pi*float64(prediction.Samples[i].Timestamp)/86400)
+				if math.Abs(v-want) > tt.tolerance {
+					t.Fatalf("got %v at %d, want %v", v, prediction.Samples[i].Timestamp, want)
+				}
+			}
+		})
+	}
+
+	config := &predictionapi.DSP{Bounds: &predictionapi.Bounds{ConfidenceLevel: "0.9"}}
+	prediction, err := PredictDSP(samples, config, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	predicted, lower, upper := values(t, prediction.Samples), values(t, prediction.LowerBound), values(t, prediction.UpperBound)
+	for i := range predicted {
+		if !(lower[i] < predicted[i] && predicted[i] < upper[i]) {
+			t.Fatalf("got %v outside of [%v, %v]", predicted[i], lower[i], upper[i])
+		}
+	}
+
+	if _, err := PredictDSP(daily(1, 0), &predictionapi.DSP{SampleInterval: "1h"}, time.Hour); !errors.Is(err, ErrInsufficientSamples) {
+		t.Errorf("got %v for a day of hourly history, want %v", err, ErrInsufficientSamples)
+	}
+	if _, err := PredictDSP(daily(3, 100), &predictionapi.DSP{}, time.Hour); !errors.Is(err, ErrNotPeriodic) {
+		t.Errorf("got %v for noise, want %v", err, ErrNotPeriodic)
+	}
+}
+
+func TestPredictPercentile(t *testing.T) {
+	// 100 samples of 1 to 100, the most recent being the largest.
+	var samples []predictionapi.Sample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, predictionapi.Sample{Timestamp: int64(i * 60), Value: timeseries.FormatValue(float64(i))})
+	}
+	config := &predictionapi.Percentile{
+		Percentile:        "0.895",
+		MarginFraction:    "0.5",
+		TargetUtilization: "0.5",
+		Histogram:         predictionapi.HistogramConfig{BucketSize: "1", HalfLife: "10000h"},
+		Bounds:            &predictionapi.Bounds{ConfidenceLevel: "0.79"},
+	}
+
+	prediction, err := PredictPercentile(samples, config, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prediction.Samples) != 5 || prediction.Samples[0].Timestamp != 6060 {
+		t.Fatalf("got samples %v, want 5 from 6060", prediction.Samples)
+	}
+	// The p89.5 is in the bucket [90, 91) and the p10.5 in [11, 12), scaled by 1.5 / 0.5.
+	if prediction.Samples[0].Value != "273" || prediction.LowerBound[0].Value != "36" || prediction.UpperBound[0].Value != "273" {
+		t.Errorf("got %s in [%s, %s], want 273 in [36, 273]", prediction.Samples[0].Value, prediction.LowerBound[0].Value, prediction.UpperBound[0].Value)
+	}
+
+	// With a short half life, the recent samples weigh most.
+	config.Histogram.HalfLife = "1m"
+	config.Percentile = "0.5"
+	if prediction, err = PredictPercentile(samples, config, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if prediction.Samples[0].Value != "300" {
+		t.Errorf("got %s with a short half life, want 300", prediction.Samples[0].Value)
+	}
+
+	list := predictionapi.MetricTimeSeriesList{{Samples: samples[:50]}, {Samples: samples[50:]}}
+	config = &predictionapi.Percentile{Aggregated: true, Percentile: "1"}
+	predictions, err := PredictPercentileSeries(list, config, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(predictions) != 1 || predictions[0].Samples[0].Value != "100" {
+		t.Errorf("got %d predictions of %v aggregated, want one of 100", len(predictions), predictions[0].Samples)
+	}
+}
+
+func TestPredictPercentileInvalidHistogram(t *testing.T) {
+	samples := []predictionapi.Sample{{Timestamp: 60, Value: "1"}, {Timestamp: 120, Value: "8e9"}}
+	tests := []struct {
+		name    string
+		samples []predictionapi.Sample
+		config  *predictionapi.Percentile
+	}{
+		{name: "infinite max value", samples: samples, config: &predictionapi.Percentile{Histogram: predictionapi.HistogramConfig{MaxValue: "+Inf", BucketSize: "1"}}},
+		{name: "infinite sample", samples: append(samples, predictionapi.Sample{Timestamp: 180, Value: "+Inf"}), config: &predictionapi.Percentile{Histogram: predictionapi.HistogramConfig{BucketSize: "1"}}},
+		{name: "too many linear buckets", samples: samples, config: &predictionapi.Percentile{Histogram: predictionapi.HistogramConfig{BucketSize: "1"}}},
+		{name: "too many exponential buckets", samples: samples, config: &predictionapi.Percentile{Histogram: predictionapi.HistogramConfig{FirstBucketSize: "1", BucketSizeGrowthRatio: "1.0001"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PredictPercentile(tt.samples, tt.config, time.Minute); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPredictZeroInterval(t *testing.T) {
+	if _, err := PredictPercentile(daily(3, 0), &predictionapi.Percentile{SampleInterval: "0s"}, time.Hour); err == nil {
+		t.Error("expected an error for a zero sample interval of Percentile")
+	}
+	if _, err := PredictDSP(daily(3, 0), &predictionapi.DSP{SampleInterval: "0s"}, time.Hour); err == nil {
+		t.Error("expected an error for a zero sample interval of DSP")
+	}
+}
+
+func TestPredictUnsupported(t *testing.T) {
+	algorithm := &predictionapi.Algorithm{AlgorithmType: predictionapi.AlgorithmTypeLinear}
+	if _, err := Predict(daily(3, 0), algorithm, time.Hour); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("got %v, want %v", err, ErrUnsupportedAlgorithm)
+	}
+}
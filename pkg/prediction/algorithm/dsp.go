@@ -0,0 +1,280 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+	"time"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	"github.com/gocrane/api/pkg/util/duration"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+const (
+	defaultDSPSampleInterval = time.Minute
+	defaultDSPHistoryLength  = 15 * duration.Day
+
+	// periodicityThreshold is the autocorrelation above which a history is periodic.
+	periodicityThreshold = 0.5
+)
+
+// candidatePeriods are the periods DSP looks for in a history, the one with the highest
+// autocorrelation wins.
+var candidatePeriods = []time.Duration{time.Hour, duration.Day, duration.Week}
+
+// estimator estimates a cycle of cycleLength values following the whole cycles of values.
+type estimator func(values []float64, cycleLength int, interval time.Duration) []float64
+
+// PredictDSP predicts the samples following samples over window with the DSP algorithm.
+//
+// The period of the history is the one of candidatePeriods with the highest autocorrelation, at
+// least two cycles of it must be in the history. Each estimator is backtested, estimating the
+// last cycle of the history from the cycles before it, and the estimator with the lowest root mean
+// square error predicts the following cycles from the whole history. Without estimators, a
+// MaxValueEstimator and an FFTEstimator without margins are backtested.
+//
+// With Bounds, the bounds are the estimation shifted by the quantiles of the backtest residuals.
+func PredictDSP(samples []predictionapi.Sample, config *predictionapi.DSP, window time.Duration) (*Prediction, error) {
+	interval, err := parseInterval(config.SampleInterval, defaultDSPSampleInterval)
+	if err != nil {
+		return nil, fmt.Errorf("sample interval: %w", err)
+	}
+	historyLength, err := parseDuration(config.HistoryLength, defaultDSPHistoryLength)
+	if err != nil {
+		return nil, fmt.Errorf("history length: %w", err)
+	}
+	estimators, err := dspEstimators(&config.Estimators)
+	if err != nil {
+		return nil, err
+	}
+	level, err := confidenceLevel(config.Bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := history(samples, interval, historyLength)
+	if err != nil {
+		return nil, err
+	}
+	values := s.Values()
+	cycleLength, err := period(values, interval)
+	if err != nil {
+		return nil, err
+	}
+	// Keep whole cycles, ending with the last sample.
+	values = values[len(values)%cycleLength:]
+
+	trainValues, lastCycle := values[:len(values)-cycleLength], values[len(values)-cycleLength:]
+	best, bestError := -1, math.Inf(1)
+	var residuals []float64
+	for i, estimate := range estimators {
+		estimation := estimate(trainValues, cycleLength, interval)
+		var squaredErrors float64
+		r := make([]float64, cycleLength)
+		for j := range lastCycle {
+			r[j] = lastCycle[j] - estimation[j]
+			squaredErrors += r[j] * r[j]
+		}
+		if rmse := math.Sqrt(squaredErrors / float64(cycleLength)); rmse < bestError {
+			best, bestError, residuals = i, rmse, r
+		}
+	}
+	if best < 0 {
+		return nil, fmt.Errorf("%w: no estimator fits the history", ErrInsufficientSamples)
+	}
+
+	estimation := estimators[best](values, cycleLength, interval)
+	last := s.Points[len(s.Points)-1].Timestamp
+	n := int(window / interval)
+	prediction := &Prediction{Samples: project(last, interval, n, func(i int) float64 { return estimation[i%cycleLength] })}
+	if level > 0 {
+		lower := timeseries.Percentile((1 - level) / 2)(residuals)
+		upper := timeseries.Percentile((1 + level) / 2)(residuals)
+		prediction.LowerBound = project(last, interval, n, func(i int) float64 { return estimation[i%cycleLength] + lower })
+		prediction.UpperBound = project(last, interval, n, func(i int) float64 { return estimation[i%cycleLength] + upper })
+	}
+	return prediction, nil
+}
+
+// period returns the length in samples of the period of values.
+func period(values []float64, interval time.Duration) (int, error) {
+	best, bestCorrelation := 0, math.Inf(-1)
+	for _, p := range candidatePeriods {
+		if p%interval != 0 {
+			continue
+		}
+		lag := int(p / interval)
+		if lag < 2 || len(values) < 2*lag {
+			continue
+		}
+		if correlation := autocorrelation(values, lag); correlation > bestCorrelation {
+			best, bestCorrelation = lag, correlation
+		}
+	}
+	if best == 0 {
+		return 0, fmt.Errorf("%w: %d samples cover less than two cycles of any period", ErrInsufficientSamples, len(values))
+	}
+	if bestCorrelation < periodicityThreshold {
+		return 0, fmt.Errorf("%w: autocorrelation %.2f", ErrNotPeriodic, bestCorrelation)
+	}
+	return best, nil
+}
+
+// autocorrelation returns the correlation of values with themselves lag samples later, 1 for
+// constant values.
+func autocorrelation(values []float64, lag int) float64 {
+	x, y := values[:len(values)-lag], values[lag:]
+	meanX, meanY := timeseries.Avg(x), timeseries.Avg(y)
+	var covariance, varianceX, varianceY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 1
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
+
+func dspEstimators(config *predictionapi.Estimators) ([]estimator, error) {
+	if len(config.MaxValueEstimators) == 0 && len(config.FFTEstimators) == 0 {
+		return []estimator{maxValueEstimator(0), fftEstimator(fftConfig{})}, nil
+	}
+	var estimators []estimator
+	for i, e := range config.MaxValueEstimators {
+		if e == nil {
+			continue
+		}
+		margin, err := parseFloat(e.MarginFraction, 0)
+		if err != nil {
+			return nil, fmt.Errorf("max value estimator %d: margin fraction: %w", i, err)
+		}
+		estimators = append(estimators, maxValueEstimator(margin))
+	}
+	for i, e := range config.FFTEstimators {
+		if e == nil {
+			continue
+		}
+		c, err := parseFFTConfig(e)
+		if err != nil {
+			return nil, fmt.Errorf("fft estimator %d: %w", i, err)
+		}
+		estimators = append(estimators, fftEstimator(c))
+	}
+	return estimators, nil
+}
+
+// maxValueEstimator estimates each sample of the cycle as the maximum of the samples at the same
+// phase in all cycles, increased by marginFraction.
+func maxValueEstimator(marginFraction float64) estimator {
+	return func(values []float64, cycleLength int, _ time.Duration) []float64 {
+		estimation := make([]float64, cycleLength)
+		for j := range estimation {
+			estimation[j] = math.Inf(-1)
+			for i := j; i < len(values); i += cycleLength {
+				estimation[j] = math.Max(estimation[j], values[i])
+			}
+			estimation[j] *= 1 + marginFraction
+		}
+		return estimation
+	}
+}
+
+type fftConfig struct {
+	marginFraction         float64
+	lowAmplitudeThreshold  float64
+	highFrequencyThreshold float64
+	minNumOfSpectrumItems  int
+	maxNumOfSpectrumItems  int
+}
+
+func parseFFTConfig(e *predictionapi.FFTEstimator) (fftConfig, error) {
+	var c fftConfig
+	var err error
+	if c.marginFraction, err = parseFloat(e.MarginFraction, 0); err != nil {
+		return c, fmt.Errorf("margin fraction: %w", err)
+	}
+	if c.lowAmplitudeThreshold, err = parseFloat(e.LowAmplitudeThreshold, 0); err != nil {
+		return c, fmt.Errorf("low amplitude threshold: %w", err)
+	}
+	if c.highFrequencyThreshold, err = parseFloat(e.HighFrequencyThreshold, 0); err != nil {
+		return c, fmt.Errorf("high frequency threshold: %w", err)
+	}
+	if e.MinNumOfSpectrumItems != nil {
+		c.minNumOfSpectrumItems = int(*e.MinNumOfSpectrumItems)
+	}
+	if e.MaxNumOfSpectrumItems != nil {
+		c.maxNumOfSpectrumItems = int(*e.MaxNumOfSpectrumItems)
+	}
+	return c, nil
+}
+
+// fftEstimator estimates the cycle from the harmonics of the period in the spectrum of the values,
+// which without filtering is the average cycle, increased by marginFraction.
+//
+// The mean is always kept. Of the other harmonics, those with a frequency in Hz above
+// highFrequencyThreshold or an amplitude below lowAmplitudeThreshold are filtered out, when the
+// thresholds are set. Then the harmonics with the highest amplitudes are kept, at most
+// maxNumOfSpectrumItems of them when set, and at least minNumOfSpectrumItems of them regardless
+// of the thresholds.
+func fftEstimator(c fftConfig) estimator {
+	return func(values []float64, cycleLength int, interval time.Duration) []float64 {
+		n := len(values)
+		cycles := n / cycleLength
+		x := make([]complex128, n)
+		for i, v := range values {
+			x[i] = complex(v, 0)
+		}
+		spectrum := fft(x)
+
+		type harmonic struct {
+			k         int
+			amplitude float64
+			filtered  bool
+		}
+		var harmonics []harmonic
+		for k := cycles; k <= n/2; k += cycles {
+			h := harmonic{k: k, amplitude: 2 * cmplx.Abs(spectrum[k]) / float64(n)}
+			if 2*k == n {
+				h.amplitude /= 2
+			}
+			frequency := float64(k) / (float64(n) * interval.Seconds())
+			h.filtered = (c.highFrequencyThreshold > 0 && frequency > c.highFrequencyThreshold) ||
+				(c.lowAmplitudeThreshold > 0 && h.amplitude < c.lowAmplitudeThreshold)
+			harmonics = append(harmonics, h)
+		}
+		sort.SliceStable(harmonics, func(i, j int) bool { return harmonics[i].amplitude > harmonics[j].amplitude })
+
+		kept := make([]complex128, n)
+		kept[0] = spectrum[0]
+		count := 0
+		keep := func(filtered bool, limit int) {
+			for _, h := range harmonics {
+				if limit > 0 && count >= limit {
+					return
+				}
+				if h.filtered == filtered {
+					kept[h.k], kept[n-h.k] = spectrum[h.k], spectrum[n-h.k]
+					count++
+				}
+			}
+		}
+		keep(false, c.maxNumOfSpectrumItems)
+		if count < c.minNumOfSpectrumItems {
+			keep(true, c.minNumOfSpectrumItems)
+		}
+
+		// The kept harmonics repeat every cycle, so the values following the history are those
+		// of its first cycle.
+		filtered := ifft(kept)
+		estimation := make([]float64, cycleLength)
+		for j := range estimation {
+			estimation[j] = real(filtered[j]) * (1 + c.marginFraction)
+		}
+		return estimation
+	}
+}
@@ -0,0 +1,108 @@
+package algorithm
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft returns the discrete Fourier transform of x, of any length.
+func fft(x []complex128) []complex128 {
+	n := len(x)
+	if n <= 1 {
+		return append([]complex128(nil), x...)
+	}
+	if n&(n-1) == 0 {
+		return radix2(x, false)
+	}
+	return bluestein(x)
+}
+
+// ifft returns the inverse discrete Fourier transform of x.
+func ifft(x []complex128) []complex128 {
+	n := len(x)
+	conjugated := make([]complex128, n)
+	for i, v := range x {
+		conjugated[i] = cmplx.Conj(v)
+	}
+	y := fft(conjugated)
+	for i, v := range y {
+		y[i] = cmplx.Conj(v) / complex(float64(n), 0)
+	}
+	return y
+}
+
+// radix2 returns the discrete Fourier transform of x, whose length is a power of 2, or its
+// inverse without the 1/n scaling.
+func radix2(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	y := make([]complex128, n)
+	// Bit reversal permutation.
+	bits := 0
+	for 1<<bits < n {
+		bits++
+	}
+	for i := range x {
+		j := 0
+		for b := 0; b < bits; b++ {
+			j |= (i >> b & 1) << (bits - 1 - b)
+		}
+		y[j] = x[i]
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := y[start+k], w*y[start+k+size/2]
+				y[start+k], y[start+k+size/2] = even+odd, even-odd
+				w *= step
+			}
+		}
+	}
+	return y
+}
+
+// bluestein returns the discrete Fourier transform of x as a convolution computed with radix-2
+// transforms, for lengths which are not a power of 2.
+func bluestein(x []complex128) []complex128 {
+	n := len(x)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+
+	// chirp[k] = exp(-iπk²/n), with k² taken modulo 2n to keep the angle precise.
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		kk := (int64(k) * int64(k)) % int64(2*n)
+		chirp[k] = cmplx.Rect(1, -math.Pi*float64(kk)/float64(n))
+	}
+
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * chirp[k]
+	}
+	b[0] = cmplx.Conj(chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(chirp[k])
+		b[m-k] = b[k]
+	}
+
+	fa, fb := radix2(a, false), radix2(b, false)
+	for i := range fa {
+		fa[i] *= fb[i]
+	}
+	conv := radix2(fa, true)
+
+	y := make([]complex128, n)
+	for k := range y {
+		y[k] = conv[k] / complex(float64(m), 0) * chirp[k]
+	}
+	return y
+}
@@ -0,0 +1,276 @@
+package algorithm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	"github.com/gocrane/api/pkg/util/duration"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+const (
+	defaultPercentileSampleInterval = time.Minute
+	defaultPercentileHistoryLength  = 7 * duration.Day
+	defaultPercentile               = 0.99
+	defaultHalfLife                 = 24 * time.Hour
+	defaultEpsilon                  = 1e-4
+	defaultBucketSizeGrowthRatio    = 1.05
+	// defaultFirstBucketSizeFraction is the size of the first exponential bucket relative to the
+	// maximum value, when FirstBucketSize is not set.
+	defaultFirstBucketSizeFraction = 1e-3
+	// maxBuckets bounds the number of buckets of a histogram, and thus its memory.
+	maxBuckets = 10000
+)
+
+// PredictPercentile predicts the samples following samples over window with the Percentile
+// algorithm: the percentile of a histogram of the history whose sample weights decay with age,
+// increased by MarginFraction and divided by TargetUtilization, as in the VPA recommender. The
+// predicted value is repeated every SampleInterval over window.
+//
+// The weight of a sample halves every HalfLife before the last sample, down to MinSampleWeight.
+// The histogram has linear buckets of BucketSize when it is set, and exponential buckets starting
+// with FirstBucketSize and growing by BucketSizeGrowthRatio otherwise, up to MaxValue, which
+// defaults to the maximum of the history. The percentile is the end of the bucket it falls in.
+// When the total weight is below Epsilon, there are not enough samples. The maximum value and the
+// samples must be finite, and the histogram must have at most 10000 buckets.
+//
+// With Bounds, the bounds are the percentiles (1 - level) / 2 and (1 + level) / 2 of the histogram,
+// adjusted the same way.
+func PredictPercentile(samples []predictionapi.Sample, config *predictionapi.Percentile, window time.Duration) (*Prediction, error) {
+	predictions, err := PredictPercentileSeries(predictionapi.MetricTimeSeriesList{{Samples: samples}}, config, window)
+	if err != nil {
+		return nil, err
+	}
+	return predictions[0], nil
+}
+
+// PredictPercentileSeries predicts list with the Percentile algorithm, see PredictPercentile. When
+// the config is Aggregated, the samples of all series of list are in a single histogram and there
+// is a single prediction, otherwise there is a prediction for each series of list.
+func PredictPercentileSeries(list predictionapi.MetricTimeSeriesList, config *predictionapi.Percentile, window time.Duration) ([]*Prediction, error) {
+	c, err := parsePercentileConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var histories []*timeseries.Series
+	for i, series := range list {
+		if series == nil {
+			continue
+		}
+		s, err := history(series.Samples, c.sampleInterval, c.historyLength)
+		if err != nil {
+			return nil, fmt.Errorf("series %d: %w", i, err)
+		}
+		histories = append(histories, s)
+	}
+	if len(histories) == 0 {
+		return nil, fmt.Errorf("%w: no series", ErrInsufficientSamples)
+	}
+
+	if !config.Aggregated {
+		predictions := make([]*Prediction, len(histories))
+		for i, s := range histories {
+			if predictions[i], err = c.predict([]*timeseries.Series{s}, window); err != nil {
+				return nil, fmt.Errorf("series %d: %w", i, err)
+			}
+		}
+		return predictions, nil
+	}
+	prediction, err := c.predict(histories, window)
+	if err != nil {
+		return nil, err
+	}
+	return []*Prediction{prediction}, nil
+}
+
+type percentileConfig struct {
+	sampleInterval        time.Duration
+	historyLength         time.Duration
+	percentile            float64
+	marginFraction        float64
+	targetUtilization     float64
+	minSampleWeight       float64
+	level                 float64
+	maxValue              float64
+	epsilon               float64
+	halfLife              time.Duration
+	bucketSize            float64
+	firstBucketSize       float64
+	bucketSizeGrowthRatio float64
+}
+
+func parsePercentileConfig(config *predictionapi.Percentile) (*percentileConfig, error) {
+	c := &percentileConfig{}
+	var err error
+	if c.sampleInterval, err = parseInterval(config.SampleInterval, defaultPercentileSampleInterval); err != nil {
+		return nil, fmt.Errorf("sample interval: %w", err)
+	}
+	if c.historyLength, err = parseDuration(config.HistoryLength, defaultPercentileHistoryLength); err != nil {
+		return nil, fmt.Errorf("history length: %w", err)
+	}
+	if c.percentile, err = parseFloat(config.Percentile, defaultPercentile); err != nil {
+		return nil, fmt.Errorf("percentile: %w", err)
+	}
+	if c.marginFraction, err = parseFloat(config.MarginFraction, 0); err != nil {
+		return nil, fmt.Errorf("margin fraction: %w", err)
+	}
+	if c.targetUtilization, err = parseFloat(config.TargetUtilization, 1); err != nil {
+		return nil, fmt.Errorf("target utilization: %w", err)
+	}
+	if c.targetUtilization <= 0 {
+		return nil, fmt.Errorf("target utilization %v is not greater than 0", c.targetUtilization)
+	}
+	if c.minSampleWeight, err = parseFloat(config.MinSampleWeight, 0); err != nil {
+		return nil, fmt.Errorf("min sample weight: %w", err)
+	}
+	if c.level, err = confidenceLevel(config.Bounds); err != nil {
+		return nil, err
+	}
+
+	histogram := &config.Histogram
+	if c.maxValue, err = parseFloat(histogram.MaxValue, 0); err != nil {
+		return nil, fmt.Errorf("histogram max value: %w", err)
+	}
+	if math.IsInf(c.maxValue, 0) || math.IsNaN(c.maxValue) {
+		return nil, fmt.Errorf("histogram max value %v is not finite", c.maxValue)
+	}
+	if c.epsilon, err = parseFloat(histogram.Epsilon, defaultEpsilon); err != nil {
+		return nil, fmt.Errorf("histogram epsilon: %w", err)
+	}
+	if c.halfLife, err = parseDuration(histogram.HalfLife, defaultHalfLife); err != nil {
+		return nil, fmt.Errorf("histogram half life: %w", err)
+	}
+	if c.bucketSize, err = parseFloat(histogram.BucketSize, 0); err != nil {
+		return nil, fmt.Errorf("histogram bucket size: %w", err)
+	}
+	if c.firstBucketSize, err = parseFloat(histogram.FirstBucketSize, 0); err != nil {
+		return nil, fmt.Errorf("histogram first bucket size: %w", err)
+	}
+	if c.bucketSizeGrowthRatio, err = parseFloat(histogram.BucketSizeGrowthRatio, defaultBucketSizeGrowthRatio); err != nil {
+		return nil, fmt.Errorf("histogram bucket size growth ratio: %w", err)
+	}
+	if c.bucketSizeGrowthRatio <= 1 {
+		return nil, fmt.Errorf("histogram bucket size growth ratio %v is not greater than 1", c.bucketSizeGrowthRatio)
+	}
+	return c, nil
+}
+
+func (c *percentileConfig) predict(histories []*timeseries.Series, window time.Duration) (*Prediction, error) {
+	var last int64
+	maxValue := c.maxValue
+	found := false
+	for _, s := range histories {
+		for _, p := range s.Points {
+			if !found || p.Timestamp > last {
+				last = p.Timestamp
+			}
+			found = true
+			if math.IsInf(p.Value, 0) || math.IsNaN(p.Value) {
+				return nil, fmt.Errorf("sample value %v at %d is not finite", p.Value, p.Timestamp)
+			}
+			if c.maxValue == 0 {
+				maxValue = math.Max(maxValue, p.Value)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: no sample", ErrInsufficientSamples)
+	}
+
+	h, err := c.newHistogram(maxValue)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range histories {
+		for _, p := range s.Points {
+			weight := math.Exp2(float64(p.Timestamp-last) / c.halfLife.Seconds())
+			h.add(p.Value, math.Max(weight, c.minSampleWeight))
+		}
+	}
+	if h.total < c.epsilon {
+		return nil, fmt.Errorf("%w: total weight %v is below epsilon", ErrInsufficientSamples, h.total)
+	}
+
+	adjust := func(v float64) float64 { return v * (1 + c.marginFraction) / c.targetUtilization }
+	n := int(window / c.sampleInterval)
+	constant := func(v float64) []predictionapi.Sample {
+		return project(last, c.sampleInterval, n, func(int) float64 { return v })
+	}
+	prediction := &Prediction{Samples: constant(adjust(h.percentile(c.percentile)))}
+	if c.level > 0 {
+		prediction.LowerBound = constant(adjust(h.percentile((1 - c.level) / 2)))
+		prediction.UpperBound = constant(adjust(h.percentile((1 + c.level) / 2)))
+	}
+	return prediction, nil
+}
+
+// histogram is a histogram of weighted values, bucket i covers [bounds[i], bounds[i+1]).
+type histogram struct {
+	bounds  []float64
+	weights []float64
+	total   float64
+}
+
+func (c *percentileConfig) newHistogram(maxValue float64) (*histogram, error) {
+	h := &histogram{bounds: []float64{0}}
+	if maxValue <= 0 {
+		h.bounds = append(h.bounds, 0)
+	} else if c.bucketSize > 0 {
+		if n := math.Ceil(maxValue / c.bucketSize); n > maxBuckets {
+			return nil, fmt.Errorf("histogram of %v buckets of %v up to %v exceeds %d buckets", n, c.bucketSize, maxValue, maxBuckets)
+		}
+		for b := c.bucketSize; ; b += c.bucketSize {
+			h.bounds = append(h.bounds, math.Min(b, maxValue))
+			if b >= maxValue {
+				break
+			}
+		}
+	} else {
+		size := c.firstBucketSize
+		if size <= 0 {
+			size = maxValue * defaultFirstBucketSizeFraction
+		}
+		// The buckets end at size * (ratio^n - 1) / (ratio - 1).
+		ratio := c.bucketSizeGrowthRatio
+		if n := math.Ceil(math.Log1p(maxValue*(ratio-1)/size) / math.Log(ratio)); n > maxBuckets {
+			return nil, fmt.Errorf("histogram of %v buckets growing by %v from %v up to %v exceeds %d buckets", n, ratio, size, maxValue, maxBuckets)
+		}
+		for b := size; ; {
+			h.bounds = append(h.bounds, math.Min(b, maxValue))
+			if b >= maxValue {
+				break
+			}
+			size *= ratio
+			b += size
+		}
+	}
+	h.weights = make([]float64, len(h.bounds)-1)
+	return h, nil
+}
+
+// add adds value with weight, values out of the buckets fall in the first or last bucket.
+func (h *histogram) add(value, weight float64) {
+	i := sort.Search(len(h.weights), func(i int) bool { return value < h.bounds[i+1] })
+	if i == len(h.weights) {
+		i--
+	}
+	h.weights[i] += weight
+	h.total += weight
+}
+
+// percentile returns the end of the first bucket at which the cumulative weight reaches p of the total.
+func (h *histogram) percentile(p float64) float64 {
+	threshold := p * h.total
+	var cumulative float64
+	for i, w := range h.weights {
+		cumulative += w
+		if cumulative >= threshold && w > 0 {
+			return h.bounds[i+1]
+		}
+	}
+	return h.bounds[len(h.bounds)-1]
+}
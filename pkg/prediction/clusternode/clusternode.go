@@ -0,0 +1,200 @@
+// Package clusternode renders the TimeSeriesPrediction of each node selected by a
+// ClusterNodePrediction and summarizes their state in its status.
+//
+// Rendering is deterministic: the same ClusterNodePrediction and node always produce the same
+// TimeSeriesPrediction, so the controller can compare it with the existing one.
+package clusternode
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var (
+	// ErrNoTemplate is returned when the ClusterNodePrediction has no template.
+	ErrNoTemplate = errors.New("no prediction template")
+	// ErrUnknownPlaceholder is returned for a placeholder other than ${node.name} and ${node.labels[<key>]}.
+	ErrUnknownPlaceholder = errors.New("unknown placeholder")
+	// ErrMissingLabel is returned when a placeholder refers to a label the node does not have.
+	ErrMissingLabel = errors.New("missing node label")
+)
+
+var (
+	placeholder      = regexp.MustCompile(`\$\{([^}]*)\}`)
+	labelPlaceholder = regexp.MustCompile(`^node\.labels\[(.+)\]$`)
+)
+
+// Selects reports whether cnp selects node.
+func Selects(cnp *predictionapi.ClusterNodePrediction, node *corev1.Node) bool {
+	return labels.SelectorFromSet(cnp.Spec.NodeSelector).Matches(labels.Set(node.Labels))
+}
+
+// Name returns the name of the TimeSeriesPrediction of the node nodeName: the name of cnp and the
+// node name joined by a dash. A name longer than a DNS subdomain is truncated and suffixed with a
+// hash of the full name to stay unique.
+func Name(cnp *predictionapi.ClusterNodePrediction, nodeName string) string {
+	name := cnp.Name + "-" + nodeName
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return name[:validation.DNS1123SubdomainMaxLength-len(suffix)] + suffix
+}
+
+// Labels returns the labels of the TimeSeriesPrediction of node, added to those of the template.
+// The node name label is left out when the node name is not a valid label value.
+func Labels(cnp *predictionapi.ClusterNodePrediction, node *corev1.Node) map[string]string {
+	l := map[string]string{predictionapi.LabelClusterNodePredictionName: cnp.Name}
+	if len(validation.IsValidLabelValue(node.Name)) == 0 {
+		l[predictionapi.LabelNodeName] = node.Name
+	}
+	return l
+}
+
+// OwnerReference returns the controller reference to cnp set on the TimeSeriesPredictions rendered for it.
+func OwnerReference(cnp *predictionapi.ClusterNodePrediction) metav1.OwnerReference {
+	return *metav1.NewControllerRef(cnp, predictionapi.SchemeGroupVersion.WithKind("ClusterNodePrediction"))
+}
+
+// TargetRef returns the target of the TimeSeriesPrediction of node.
+func TargetRef(node *corev1.Node) corev1.ObjectReference {
+	return corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: node.Name}
+}
+
+// Render returns the TimeSeriesPrediction of node rendered from the template of cnp.
+func Render(cnp *predictionapi.ClusterNodePrediction, node *corev1.Node) (*predictionapi.TimeSeriesPrediction, error) {
+	if cnp.Spec.PredictionTemplate == nil {
+		return nil, ErrNoTemplate
+	}
+	template := cnp.Spec.PredictionTemplate.DeepCopy()
+
+	tsp := &predictionapi.TimeSeriesPrediction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       cnp.Namespace,
+			Name:            Name(cnp, node.Name),
+			Labels:          template.Labels,
+			Annotations:     template.Annotations,
+			OwnerReferences: []metav1.OwnerReference{OwnerReference(cnp)},
+		},
+		Spec: template.Spec,
+	}
+	if tsp.Labels == nil {
+		tsp.Labels = map[string]string{}
+	}
+	for k, v := range Labels(cnp, node) {
+		tsp.Labels[k] = v
+	}
+	tsp.Spec.TargetRef = TargetRef(node)
+
+	for i := range tsp.Spec.PredictionMetrics {
+		metric := &tsp.Spec.PredictionMetrics[i]
+		if metric.ExpressionQuery != nil {
+			expression, err := Substitute(metric.ExpressionQuery.Expression, node)
+			if err != nil {
+				return nil, fmt.Errorf("metric %s: %w", metric.ResourceIdentifier, err)
+			}
+			metric.ExpressionQuery.Expression = expression
+		}
+		if metric.MetricQuery != nil {
+			for j := range metric.MetricQuery.QueryConditions {
+				values := metric.MetricQuery.QueryConditions[j].Value
+				for k := range values {
+					value, err := Substitute(values[k], node)
+					if err != nil {
+						return nil, fmt.Errorf("metric %s: %w", metric.ResourceIdentifier, err)
+					}
+					values[k] = value
+				}
+			}
+		}
+	}
+	return tsp, nil
+}
+
+// Substitute replaces the placeholders ${node.name} and ${node.labels[<key>]} in s with the name
+// and the labels of node.
+func Substitute(s string, node *corev1.Node) (string, error) {
+	var err error
+	substituted := placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		if name == "node.name" {
+			return node.Name
+		}
+		if m := labelPlaceholder.FindStringSubmatch(name); m != nil {
+			value, ok := node.Labels[m[1]]
+			if !ok && err == nil {
+				err = fmt.Errorf("%w %q of node %s", ErrMissingLabel, m[1], node.Name)
+			}
+			return value
+		}
+		if err == nil {
+			err = fmt.Errorf("%w %s", ErrUnknownPlaceholder, match)
+		}
+		return match
+	})
+	if err != nil {
+		return "", err
+	}
+	return substituted, nil
+}
+
+// CheckPlaceholders returns an error for an unknown placeholder in s.
+func CheckPlaceholders(s string) error {
+	for _, m := range placeholder.FindAllStringSubmatch(s, -1) {
+		if m[1] != "node.name" && !labelPlaceholder.MatchString(m[1]) {
+			return fmt.Errorf("%w %s", ErrUnknownPlaceholder, m[0])
+		}
+	}
+	return nil
+}
+
+// NodeStatus returns the state of the TimeSeriesPrediction of the node nodeName: tsp is the existing
+// TimeSeriesPrediction, nil if there is none, and renderErr the error of Render for the node.
+func NodeStatus(cnp *predictionapi.ClusterNodePrediction, nodeName string, tsp *predictionapi.TimeSeriesPrediction, renderErr error) predictionapi.ClusterNodePredictionNodeStatus {
+	status := predictionapi.ClusterNodePredictionNodeStatus{NodeName: nodeName, PredictionName: Name(cnp, nodeName)}
+	switch {
+	case renderErr != nil:
+		status.Phase = predictionapi.ClusterNodePredictionNodeFailed
+		status.Message = renderErr.Error()
+	case tsp == nil:
+		status.Phase = predictionapi.ClusterNodePredictionNodePending
+	case meta.IsStatusConditionTrue(tsp.Status.Conditions, string(predictionapi.TimeSeriesPredictionConditionReady)):
+		status.Phase = predictionapi.ClusterNodePredictionNodeReady
+	default:
+		status.Phase = predictionapi.ClusterNodePredictionNodeCreated
+	}
+	return status
+}
+
+// SetNodeStatuses sets the node states of status, one per selected node, and the numbers derived from them.
+func SetNodeStatuses(status *predictionapi.ClusterNodePredictionStatus, nodes []predictionapi.ClusterNodePredictionNodeStatus) {
+	nodes = append([]predictionapi.ClusterNodePredictionNodeStatus(nil), nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].NodeName < nodes[j].NodeName })
+
+	status.Nodes = nodes
+	status.DesiredNumberCreated = len(nodes)
+	status.CurrentNumberCreated = 0
+	status.ReadyNumber = 0
+	for _, node := range nodes {
+		switch node.Phase {
+		case predictionapi.ClusterNodePredictionNodeReady:
+			status.ReadyNumber++
+			status.CurrentNumberCreated++
+		case predictionapi.ClusterNodePredictionNodeCreated:
+			status.CurrentNumberCreated++
+		}
+	}
+}
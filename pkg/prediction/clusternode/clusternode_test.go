@@ -0,0 +1,139 @@
+package clusternode
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func newClusterNodePrediction() *predictionapi.ClusterNodePrediction {
+	return &predictionapi.ClusterNodePrediction{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crane-system", Name: "nodes", UID: "uid-1"},
+		Spec: predictionapi.ClusterNodePredictionSpec{
+			NodeSelector: map[string]string{"pool": "web"},
+			PredictionTemplate: &predictionapi.PredictionTemplate{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "infra"}},
+				Spec: predictionapi.TimeSeriesPredictionSpec{
+					PredictionWindowSeconds: 3600,
+					PredictionMetrics: []predictionapi.PredictionMetric{
+						{
+							ResourceIdentifier: "cpu",
+							Type:               predictionapi.ExpressionQueryMetricType,
+							ExpressionQuery: &predictionapi.ExpressionQuery{
+								Expression: `sum(rate(node_cpu_seconds_total{node="${node.name}",zone="${node.labels[topology.kubernetes.io/zone]}"}[1m]))`,
+							},
+						},
+						{
+							ResourceIdentifier: "load",
+							Type:               predictionapi.MetricQueryMetricType,
+							MetricQuery: &predictionapi.MetricQuery{
+								MetricName:      "node_load1",
+								QueryConditions: []predictionapi.QueryCondition{{Key: "instance", Operator: predictionapi.OperatorEqual, Value: []string{"${node.name}:9100"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newNode() *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-1",
+		Labels: map[string]string{"pool": "web", "topology.kubernetes.io/zone": "zone-a"},
+	}}
+}
+
+func TestRender(t *testing.T) {
+	cnp, node := newClusterNodePrediction(), newNode()
+	if !Selects(cnp, node) {
+		t.Fatal("node should be selected")
+	}
+
+	tsp, err := Render(cnp, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tsp.Namespace != "crane-system" || tsp.Name != "nodes-node-1" {
+		t.Errorf("unexpected name %s/%s", tsp.Namespace, tsp.Name)
+	}
+	wantLabels := map[string]string{"team": "infra", predictionapi.LabelClusterNodePredictionName: "nodes", predictionapi.LabelNodeName: "node-1"}
+	if !reflect.DeepEqual(tsp.Labels, wantLabels) {
+		t.Errorf("got labels %v, want %v", tsp.Labels, wantLabels)
+	}
+	if len(tsp.OwnerReferences) != 1 || tsp.OwnerReferences[0].UID != "uid-1" || tsp.OwnerReferences[0].Kind != "ClusterNodePrediction" {
+		t.Errorf("unexpected owner references %v", tsp.OwnerReferences)
+	}
+	if want := (corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: "node-1"}); tsp.Spec.TargetRef != want {
+		t.Errorf("got target %v, want %v", tsp.Spec.TargetRef, want)
+	}
+	wantExpression := `sum(rate(node_cpu_seconds_total{node="node-1",zone="zone-a"}[1m]))`
+	if got := tsp.Spec.PredictionMetrics[0].ExpressionQuery.Expression; got != wantExpression {
+		t.Errorf("got expression %s, want %s", got, wantExpression)
+	}
+	if got := tsp.Spec.PredictionMetrics[1].MetricQuery.QueryConditions[0].Value; !reflect.DeepEqual(got, []string{"node-1:9100"}) {
+		t.Errorf("got condition values %v, want [node-1:9100]", got)
+	}
+	if strings.Contains(cnp.Spec.PredictionTemplate.Spec.PredictionMetrics[0].ExpressionQuery.Expression, "node-1") {
+		t.Error("the template should not be modified")
+	}
+
+	delete(node.Labels, "topology.kubernetes.io/zone")
+	if _, err := Render(cnp, node); !errors.Is(err, ErrMissingLabel) {
+		t.Errorf("got %v, want %v", err, ErrMissingLabel)
+	}
+	if _, err := Substitute("${node.uid}", node); !errors.Is(err, ErrUnknownPlaceholder) {
+		t.Errorf("got %v, want %v", err, ErrUnknownPlaceholder)
+	}
+}
+
+func TestName(t *testing.T) {
+	cnp := newClusterNodePrediction()
+	long := strings.Repeat("n", 253)
+	name := Name(cnp, long)
+	if len(name) != 253 || name == Name(cnp, long[:252]) {
+		t.Errorf("got %s, want a unique name of 253 characters", name)
+	}
+}
+
+func TestSetNodeStatuses(t *testing.T) {
+	cnp := newClusterNodePrediction()
+	ready := &predictionapi.TimeSeriesPrediction{}
+	ready.Status.Conditions = []metav1.Condition{{Type: string(predictionapi.TimeSeriesPredictionConditionReady), Status: metav1.ConditionTrue}}
+
+	nodes := []predictionapi.ClusterNodePredictionNodeStatus{
+		NodeStatus(cnp, "node-4", nil, ErrMissingLabel),
+		NodeStatus(cnp, "node-3", nil, nil),
+		NodeStatus(cnp, "node-2", &predictionapi.TimeSeriesPrediction{}, nil),
+		NodeStatus(cnp, "node-1", ready, nil),
+	}
+	var status predictionapi.ClusterNodePredictionStatus
+	SetNodeStatuses(&status, nodes)
+
+	if status.DesiredNumberCreated != 4 || status.CurrentNumberCreated != 2 || status.ReadyNumber != 1 {
+		t.Errorf("got desired %d, current %d and ready %d, want 4, 2 and 1", status.DesiredNumberCreated, status.CurrentNumberCreated, status.ReadyNumber)
+	}
+	var phases []predictionapi.ClusterNodePredictionNodePhase
+	for _, node := range status.Nodes {
+		phases = append(phases, node.Phase)
+	}
+	want := []predictionapi.ClusterNodePredictionNodePhase{
+		predictionapi.ClusterNodePredictionNodeReady,
+		predictionapi.ClusterNodePredictionNodeCreated,
+		predictionapi.ClusterNodePredictionNodePending,
+		predictionapi.ClusterNodePredictionNodeFailed,
+	}
+	if !reflect.DeepEqual(phases, want) {
+		t.Errorf("got phases %v, want %v", phases, want)
+	}
+	if status.Nodes[3].Message == "" || status.Nodes[0].PredictionName != "nodes-node-1" {
+		t.Errorf("unexpected node statuses %+v", status.Nodes)
+	}
+}
@@ -0,0 +1,182 @@
+package format
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+const (
+	columnMetric    = "metric"
+	columnKind      = "kind"
+	columnTimestamp = "timestamp"
+	columnValue     = "value"
+	// labelColumnPrefix prefixes the names of the label columns. A colon cannot be part of a label
+	// name, so label columns never clash with the other columns.
+	labelColumnPrefix = "label:"
+)
+
+// WriteCSV writes list to w as CSV.
+func WriteCSV(w io.Writer, list predictionapi.MetricTimeSeriesList) error {
+	entries, err := listEntries(list)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, entries, false)
+}
+
+// WriteStatusCSV writes the predicted series of status to w as CSV.
+func WriteStatusCSV(w io.Writer, status *predictionapi.TimeSeriesPredictionStatus) error {
+	entries, err := statusEntries(status)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, entries, true)
+}
+
+// ReadCSV reads a list written by WriteCSV from r. The series are in the order of their first sample.
+func ReadCSV(r io.Reader) (predictionapi.MetricTimeSeriesList, error) {
+	entries, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	list := make(predictionapi.MetricTimeSeriesList, len(entries))
+	for i, e := range entries {
+		list[i] = e.series
+	}
+	return list, nil
+}
+
+// ReadStatusCSV reads a status written by WriteStatusCSV from r.
+func ReadStatusCSV(r io.Reader) (*predictionapi.TimeSeriesPredictionStatus, error) {
+	entries, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	return status(entries)
+}
+
+func writeCSV(w io.Writer, entries []entry, withMetric bool) error {
+	names := labelNames(entries)
+	var header []string
+	if withMetric {
+		header = append(header, columnMetric, columnKind)
+	}
+	header = append(header, columnTimestamp, columnValue)
+	for _, name := range names {
+		header = append(header, labelColumnPrefix+name)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		labels := make(map[string]string, len(e.series.Labels))
+		for _, l := range e.series.Labels {
+			labels[l.Name] = l.Value
+		}
+		for _, sample := range e.series.Samples {
+			var record []string
+			if withMetric {
+				record = append(record, e.metric, e.kind)
+			}
+			record = append(record, strconv.FormatInt(sample.Timestamp, 10), sample.Value)
+			for _, name := range names {
+				record = append(record, labels[name])
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readCSV(r io.Reader) ([]entry, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errorf("%v", err)
+	}
+
+	metricColumn, kindColumn, timestampColumn, valueColumn := -1, -1, -1, -1
+	labelColumns := map[int]string{}
+	for i, column := range header {
+		switch {
+		case column == columnMetric:
+			metricColumn = i
+		case column == columnKind:
+			kindColumn = i
+		case column == columnTimestamp:
+			timestampColumn = i
+		case column == columnValue:
+			valueColumn = i
+		case strings.HasPrefix(column, labelColumnPrefix):
+			labelColumns[i] = strings.TrimPrefix(column, labelColumnPrefix)
+		default:
+			return nil, errorf("unknown column %q", column)
+		}
+	}
+	if timestampColumn < 0 || valueColumn < 0 {
+		return nil, errorf("missing %s or %s column", columnTimestamp, columnValue)
+	}
+
+	var entries []entry
+	index := map[string]int{}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, errorf("%v", err)
+		}
+		timestamp, err := strconv.ParseInt(record[timestampColumn], 10, 64)
+		if err != nil {
+			return nil, errorf("line %d: invalid timestamp %q", line, record[timestampColumn])
+		}
+		if _, err := timeseries.ParseValue(record[valueColumn]); err != nil {
+			return nil, errorf("line %d: %v", line, err)
+		}
+
+		var e entry
+		if metricColumn >= 0 {
+			e.metric = record[metricColumn]
+		}
+		if kindColumn >= 0 {
+			e.kind = record[kindColumn]
+		}
+		var labels []predictionapi.Label
+		for i, name := range labelColumns {
+			if record[i] != "" {
+				labels = append(labels, predictionapi.Label{Name: name, Value: record[i]})
+			}
+		}
+		labels = sortedLabels(labels)
+
+		key := strconv.Quote(e.metric) + strconv.Quote(e.kind) + timeseries.LabelsKey(labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(entries)
+			index[key] = i
+			e.series = &predictionapi.MetricTimeSeries{Labels: labels}
+			entries = append(entries, e)
+		}
+		series := entries[i].series
+		series.Samples = append(series.Samples, predictionapi.Sample{Timestamp: timestamp, Value: record[valueColumn]})
+	}
+}
+
+func errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidFile}, args...)...)
+}
@@ -0,0 +1,132 @@
+// Package format converts MetricTimeSeriesLists and the status of TimeSeriesPredictions to and from
+// CSV and JSON files, to export predictions for analysis and to import recorded series as fixtures.
+//
+// CSV files have a row per sample, with a timestamp column, a value column and a label:<name>
+// column per label name, sorted by name. The label columns of a series without the label are
+// empty, and empty label values are left out on import. Status files also have a metric column,
+// the ResourceIdentifier of the metric, and a kind column telling prediction, upperBound or
+// lowerBound samples apart.
+//
+// JSON files are shaped like a Prometheus remote read response, with a result per metric of a
+// status. Timestamps are in milliseconds, truncated to seconds on import, and values are strings,
+// as in the Prometheus HTTP API, to carry NaN and infinities. ReadJSON also reads the matrix
+// responses of the Prometheus HTTP API, as returned by query_range.
+//
+// Series in the compact encoding are expanded on export, and labels are sorted by name. Series
+// without samples are left out of CSV files.
+package format
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// Kinds of the series of a status.
+const (
+	KindPrediction = "prediction"
+	KindUpperBound = "upperBound"
+	KindLowerBound = "lowerBound"
+)
+
+// ErrInvalidFile is returned for a file that cannot be read.
+var ErrInvalidFile = errors.New("invalid file")
+
+// entry is a series with the metric and the kind it belongs to in a status.
+type entry struct {
+	metric string
+	kind   string
+	series *predictionapi.MetricTimeSeries
+}
+
+// statusEntries returns the series of status.
+func statusEntries(status *predictionapi.TimeSeriesPredictionStatus) ([]entry, error) {
+	var entries []entry
+	for _, metric := range status.PredictionMetrics {
+		for _, kind := range []struct {
+			name string
+			list predictionapi.MetricTimeSeriesList
+		}{
+			{KindPrediction, metric.Prediction},
+			{KindUpperBound, metric.UpperBound},
+			{KindLowerBound, metric.LowerBound},
+		} {
+			list, err := timeseries.ExpandList(kind.list)
+			if err != nil {
+				return nil, err
+			}
+			for _, series := range list {
+				if series != nil {
+					entries = append(entries, entry{metric: metric.ResourceIdentifier, kind: kind.name, series: series})
+				}
+			}
+		}
+	}
+	return entries, nil
+}
+
+// listEntries returns the series of list.
+func listEntries(list predictionapi.MetricTimeSeriesList) ([]entry, error) {
+	list, err := timeseries.ExpandList(list)
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	for _, series := range list {
+		if series != nil {
+			entries = append(entries, entry{series: series})
+		}
+	}
+	return entries, nil
+}
+
+// status returns the status of entries, with the metrics in the order of their first series.
+func status(entries []entry) (*predictionapi.TimeSeriesPredictionStatus, error) {
+	s := &predictionapi.TimeSeriesPredictionStatus{}
+	index := map[string]int{}
+	for _, e := range entries {
+		i, ok := index[e.metric]
+		if !ok {
+			i = len(s.PredictionMetrics)
+			index[e.metric] = i
+			s.PredictionMetrics = append(s.PredictionMetrics, predictionapi.PredictionMetricStatus{ResourceIdentifier: e.metric})
+		}
+		metric := &s.PredictionMetrics[i]
+		switch e.kind {
+		case KindPrediction, "":
+			metric.Prediction = append(metric.Prediction, e.series)
+		case KindUpperBound:
+			metric.UpperBound = append(metric.UpperBound, e.series)
+		case KindLowerBound:
+			metric.LowerBound = append(metric.LowerBound, e.series)
+		default:
+			return nil, errorf("unknown kind %q", e.kind)
+		}
+	}
+	return s, nil
+}
+
+// labelNames returns the sorted names of the labels of entries.
+func labelNames(entries []entry) []string {
+	names := map[string]bool{}
+	for _, e := range entries {
+		for _, l := range e.series.Labels {
+			names[l.Name] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// sortedLabels returns a copy of labels sorted by name, leaving labels untouched.
+func sortedLabels(labels []predictionapi.Label) []predictionapi.Label {
+	labels = append([]predictionapi.Label(nil), labels...)
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
@@ -0,0 +1,128 @@
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func newStatus() *predictionapi.TimeSeriesPredictionStatus {
+	web0 := []predictionapi.Label{{Name: "namespace", Value: "default"}, {Name: "pod", Value: "web-0"}}
+	return &predictionapi.TimeSeriesPredictionStatus{
+		PredictionMetrics: []predictionapi.PredictionMetricStatus{
+			{
+				ResourceIdentifier: "cpu",
+				Prediction: []*predictionapi.MetricTimeSeries{
+					{Labels: web0, Samples: []predictionapi.Sample{{Timestamp: 60, Value: "1.5"}, {Timestamp: 120, Value: "NaN"}}},
+					{Labels: []predictionapi.Label{{Name: "pod", Value: "web,1"}}, Compact: &predictionapi.CompactSamples{Start: 60, Step: 60, Values: "2,3"}},
+				},
+				UpperBound: []*predictionapi.MetricTimeSeries{{Labels: web0, Samples: []predictionapi.Sample{{Timestamp: 60, Value: "2"}}}},
+			},
+			{
+				ResourceIdentifier: "memory",
+				Prediction:         []*predictionapi.MetricTimeSeries{{Samples: []predictionapi.Sample{{Timestamp: 60, Value: "1e+09"}}}},
+			},
+		},
+	}
+}
+
+// expanded is newStatus with its compact series expanded.
+func expanded() *predictionapi.TimeSeriesPredictionStatus {
+	status := newStatus()
+	status.PredictionMetrics[0].Prediction[1] = &predictionapi.MetricTimeSeries{
+		Labels:  []predictionapi.Label{{Name: "pod", Value: "web,1"}},
+		Samples: []predictionapi.Sample{{Timestamp: 60, Value: "2"}, {Timestamp: 120, Value: "3"}},
+	}
+	return status
+}
+
+func TestStatusCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusCSV(&buf, newStatus()); err != nil {
+		t.Fatal(err)
+	}
+	want := `metric,kind,timestamp,value,label:namespace,label:pod
+cpu,prediction,60,1.5,default,web-0
+cpu,prediction,120,NaN,default,web-0
+cpu,prediction,60,2,,"web,1"
+cpu,prediction,120,3,,"web,1"
+cpu,upperBound,60,2,default,web-0
+memory,prediction,60,1e+09,,
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	status, err := ReadStatusCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := expanded(); !reflect.DeepEqual(status, want) {
+		t.Errorf("got %+v after a round trip, want %+v", status, want)
+	}
+}
+
+func TestStatusJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteStatusJSON(&buf, newStatus()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"timestamp": 60000`) {
+		t.Errorf("got %s, want timestamps in milliseconds", buf.String())
+	}
+
+	status, err := ReadStatusJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := expanded(); !reflect.DeepEqual(status, want) {
+		t.Errorf("got %+v after a round trip, want %+v", status, want)
+	}
+
+	unsorted := []predictionapi.Label{{Name: "pod", Value: "web-0"}, {Name: "namespace", Value: "default"}}
+	if err := WriteJSON(&bytes.Buffer{}, predictionapi.MetricTimeSeriesList{{Labels: unsorted}}); err != nil {
+		t.Fatal(err)
+	}
+	if unsorted[0].Name != "pod" {
+		t.Errorf("got labels %v, want the labels of the caller left unsorted", unsorted)
+	}
+}
+
+func TestListCSV(t *testing.T) {
+	list := expanded().PredictionMetrics[0].Prediction
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, list); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, predictionapi.MetricTimeSeriesList(list)) {
+		t.Errorf("got %+v after a round trip, want %+v", got, list)
+	}
+
+	if _, err := ReadCSV(strings.NewReader("timestamp,value,pod\n60,1,web-0\n")); err == nil {
+		t.Error("expected an error for a label column without prefix")
+	}
+}
+
+func TestReadMatrixJSON(t *testing.T) {
+	matrix := `{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{"pod":"web-0","__name__":"cpu"},"values":[[1600000000.5,"0.25"],[1600000060,"+Inf"]]}
+	]}}`
+	list, err := ReadJSON(strings.NewReader(matrix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := predictionapi.MetricTimeSeriesList{{
+		Labels:  []predictionapi.Label{{Name: "__name__", Value: "cpu"}, {Name: "pod", Value: "web-0"}},
+		Samples: []predictionapi.Sample{{Timestamp: 1600000000, Value: "0.25"}, {Timestamp: 1600000060, Value: "+Inf"}},
+	}}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("got %+v, want %+v", list, want)
+	}
+}
@@ -0,0 +1,227 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/gocrane/api/pkg/prediction/timeseries"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// readResponse is a Prometheus remote read response, with a result per metric.
+type readResponse struct {
+	Results []queryResult `json:"results"`
+}
+
+type queryResult struct {
+	ResourceIdentifier string       `json:"resourceIdentifier,omitempty"`
+	Timeseries         []timeSeries `json:"timeseries"`
+	UpperBound         []timeSeries `json:"upperBound,omitempty"`
+	LowerBound         []timeSeries `json:"lowerBound,omitempty"`
+}
+
+type timeSeries struct {
+	Labels  []label  `json:"labels"`
+	Samples []sample `json:"samples"`
+}
+
+type label struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type sample struct {
+	Value string `json:"value"`
+	// Timestamp is in milliseconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// matrixResponse is a matrix response of the Prometheus HTTP API.
+type matrixResponse struct {
+	Data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			// Values are pairs of a timestamp in seconds and a value.
+			Values [][2]json.RawMessage `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// WriteJSON writes list to w as JSON, in a single result.
+func WriteJSON(w io.Writer, list predictionapi.MetricTimeSeriesList) error {
+	entries, err := listEntries(list)
+	if err != nil {
+		return err
+	}
+	result := queryResult{Timeseries: []timeSeries{}}
+	for _, e := range entries {
+		result.Timeseries = append(result.Timeseries, toJSON(e.series))
+	}
+	return writeJSON(w, readResponse{Results: []queryResult{result}})
+}
+
+// WriteStatusJSON writes the predicted series of status to w as JSON, with a result per metric.
+func WriteStatusJSON(w io.Writer, status *predictionapi.TimeSeriesPredictionStatus) error {
+	entries, err := statusEntries(status)
+	if err != nil {
+		return err
+	}
+	response := readResponse{Results: []queryResult{}}
+	index := map[string]int{}
+	for _, e := range entries {
+		i, ok := index[e.metric]
+		if !ok {
+			i = len(response.Results)
+			index[e.metric] = i
+			response.Results = append(response.Results, queryResult{ResourceIdentifier: e.metric, Timeseries: []timeSeries{}})
+		}
+		result := &response.Results[i]
+		switch e.kind {
+		case KindUpperBound:
+			result.UpperBound = append(result.UpperBound, toJSON(e.series))
+		case KindLowerBound:
+			result.LowerBound = append(result.LowerBound, toJSON(e.series))
+		default:
+			result.Timeseries = append(result.Timeseries, toJSON(e.series))
+		}
+	}
+	return writeJSON(w, response)
+}
+
+// ReadJSON reads a list written by WriteJSON, or a Prometheus matrix response, from r. The series of
+// all results are in the list.
+func ReadJSON(r io.Reader) (predictionapi.MetricTimeSeriesList, error) {
+	entries, err := readJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	var list predictionapi.MetricTimeSeriesList
+	for _, e := range entries {
+		if e.kind == KindPrediction {
+			list = append(list, e.series)
+		}
+	}
+	return list, nil
+}
+
+// ReadStatusJSON reads a status written by WriteStatusJSON from r.
+func ReadStatusJSON(r io.Reader) (*predictionapi.TimeSeriesPredictionStatus, error) {
+	entries, err := readJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	return status(entries)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func readJSON(r io.Reader) ([]entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, errorf("%v", err)
+	}
+	if _, ok := probe["data"]; ok {
+		return readMatrix(data)
+	}
+
+	var response readResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, errorf("%v", err)
+	}
+	var entries []entry
+	for _, result := range response.Results {
+		for _, kind := range []struct {
+			name   string
+			series []timeSeries
+		}{
+			{KindPrediction, result.Timeseries},
+			{KindUpperBound, result.UpperBound},
+			{KindLowerBound, result.LowerBound},
+		} {
+			for _, ts := range kind.series {
+				series, err := fromJSON(ts)
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, entry{metric: result.ResourceIdentifier, kind: kind.name, series: series})
+			}
+		}
+	}
+	return entries, nil
+}
+
+func readMatrix(data []byte) ([]entry, error) {
+	var response matrixResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, errorf("%v", err)
+	}
+	if response.Data.ResultType != "matrix" {
+		return nil, errorf("result type %q is not matrix", response.Data.ResultType)
+	}
+
+	var entries []entry
+	for _, result := range response.Data.Result {
+		series := &predictionapi.MetricTimeSeries{}
+		for name, value := range result.Metric {
+			series.Labels = append(series.Labels, predictionapi.Label{Name: name, Value: value})
+		}
+		series.Labels = sortedLabels(series.Labels)
+		for _, pair := range result.Values {
+			var seconds float64
+			var value string
+			if err := json.Unmarshal(pair[0], &seconds); err != nil {
+				return nil, errorf("invalid timestamp %s", pair[0])
+			}
+			if err := json.Unmarshal(pair[1], &value); err != nil {
+				return nil, errorf("invalid value %s", pair[1])
+			}
+			if _, err := timeseries.ParseValue(value); err != nil {
+				return nil, errorf("%v", err)
+			}
+			series.Samples = append(series.Samples, predictionapi.Sample{Timestamp: int64(math.Floor(seconds)), Value: value})
+		}
+		entries = append(entries, entry{kind: KindPrediction, series: series})
+	}
+	return entries, nil
+}
+
+func toJSON(series *predictionapi.MetricTimeSeries) timeSeries {
+	ts := timeSeries{Labels: []label{}, Samples: []sample{}}
+	labels := sortedLabels(series.Labels)
+	for _, l := range labels {
+		ts.Labels = append(ts.Labels, label{Name: l.Name, Value: l.Value})
+	}
+	for _, s := range series.Samples {
+		ts.Samples = append(ts.Samples, sample{Value: s.Value, Timestamp: s.Timestamp * 1000})
+	}
+	return ts
+}
+
+func fromJSON(ts timeSeries) (*predictionapi.MetricTimeSeries, error) {
+	series := &predictionapi.MetricTimeSeries{}
+	for _, l := range ts.Labels {
+		series.Labels = append(series.Labels, predictionapi.Label{Name: l.Name, Value: l.Value})
+	}
+	for _, s := range ts.Samples {
+		if _, err := timeseries.ParseValue(s.Value); err != nil {
+			return nil, errorf("%v", err)
+		}
+		// Samples are at whole seconds, the milliseconds are truncated.
+		seconds := s.Timestamp / 1000
+		if s.Timestamp < 0 && s.Timestamp%1000 != 0 {
+			seconds--
+		}
+		series.Samples = append(series.Samples, predictionapi.Sample{Value: s.Value, Timestamp: seconds})
+	}
+	return series, nil
+}
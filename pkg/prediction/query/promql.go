@@ -0,0 +1,80 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// PromQLDialect is the name the PromQL dialect is registered under.
+const PromQLDialect = "prometheus"
+
+func init() {
+	Register(PromQLDialect, PromQL{})
+}
+
+var (
+	metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegexp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// PromQL renders metric queries to PromQL instant vector selectors, e.g.
+//
+//	http_requests_total{namespace="default",code=~"2..",method=~"GET|HEAD"}
+//
+// Conditions are rendered in their order. The values of the in operator are
+// matched literally by a regular expression alternation.
+type PromQL struct{}
+
+// Render renders query to a PromQL selector.
+func (PromQL) Render(query *predictionapi.MetricQuery) (string, error) {
+	if query.MetricName == "" {
+		return "", ErrEmptyMetricName
+	}
+	if !metricNameRegexp.MatchString(query.MetricName) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidMetricName, query.MetricName)
+	}
+	if len(query.QueryConditions) == 0 {
+		return query.MetricName, nil
+	}
+
+	matchers := make([]string, 0, len(query.QueryConditions))
+	for i := range query.QueryConditions {
+		matcher, err := promQLMatcher(&query.QueryConditions[i])
+		if err != nil {
+			return "", err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return query.MetricName + "{" + strings.Join(matchers, ",") + "}", nil
+}
+
+// promQLMatcher renders condition to a PromQL label matcher.
+func promQLMatcher(condition *predictionapi.QueryCondition) (string, error) {
+	if !labelNameRegexp.MatchString(condition.Key) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidLabelName, condition.Key)
+	}
+	if len(condition.Value) == 0 {
+		return "", fmt.Errorf("%w: %s %s", ErrEmptyValue, condition.Key, condition.Operator)
+	}
+
+	switch condition.Operator {
+	case predictionapi.OperatorEqual, predictionapi.OperatorNotEqual,
+		predictionapi.OperatorRegexMatch, predictionapi.OperatorNotRegexMatch:
+		if len(condition.Value) > 1 {
+			return "", fmt.Errorf("%w: %s %s takes a single value, got %d", ErrTooManyValues, condition.Key, condition.Operator, len(condition.Value))
+		}
+		return condition.Key + string(condition.Operator) + strconv.Quote(condition.Value[0]), nil
+	case predictionapi.OperatorIn:
+		alternatives := make([]string, len(condition.Value))
+		for i, value := range condition.Value {
+			alternatives[i] = regexp.QuoteMeta(value)
+		}
+		return condition.Key + string(predictionapi.OperatorRegexMatch) + strconv.Quote(strings.Join(alternatives, "|")), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedOperator, condition.Operator)
+	}
+}
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func TestPromQLRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   predictionapi.MetricQuery
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "metric name only",
+			query: predictionapi.MetricQuery{MetricName: "up"},
+			want:  "up",
+		},
+		{
+			name: "all operators",
+			query: predictionapi.MetricQuery{
+				MetricName: "http_requests_total",
+				QueryConditions: []predictionapi.QueryCondition{
+					{Key: "namespace", Operator: predictionapi.OperatorEqual, Value: []string{"default"}},
+					{Key: "pod", Operator: predictionapi.OperatorNotEqual, Value: []string{""}},
+					{Key: "code", Operator: predictionapi.OperatorRegexMatch, Value: []string{"2.."}},
+					{Key: "path", Operator: predictionapi.OperatorNotRegexMatch, Value: []string{"/health.*"}},
+					{Key: "method", Operator: predictionapi.OperatorIn, Value: []string{"GET", "HEAD"}},
+				},
+			},
+			want: `http_requests_total{namespace="default",pod!="",code=~"2..",path!~"/health.*",method=~"GET|HEAD"}`,
+		},
+		{
+			name: "escaping",
+			query: predictionapi.MetricQuery{
+				MetricName: "app:requests:rate5m",
+				QueryConditions: []predictionapi.QueryCondition{
+					{Key: "quote", Operator: predictionapi.OperatorEqual, Value: []string{`say "hi"` + "\n" + `\o/`}},
+					{Key: "host", Operator: predictionapi.OperatorIn, Value: []string{"a.example.com", "b|c"}},
+				},
+			},
+			want: `app:requests:rate5m{quote="say \"hi\"\n\\o/",host=~"a\\.example\\.com|b\\|c"}`,
+		},
+		{
+			name:    "empty metric name",
+			query:   predictionapi.MetricQuery{},
+			wantErr: ErrEmptyMetricName,
+		},
+		{
+			name:    "invalid metric name",
+			query:   predictionapi.MetricQuery{MetricName: "http-requests"},
+			wantErr: ErrInvalidMetricName,
+		},
+		{
+			name: "invalid label name",
+			query: predictionapi.MetricQuery{
+				MetricName:      "up",
+				QueryConditions: []predictionapi.QueryCondition{{Key: "app.kubernetes.io/name", Operator: predictionapi.OperatorEqual, Value: []string{"web"}}},
+			},
+			wantErr: ErrInvalidLabelName,
+		},
+		{
+			name: "empty value",
+			query: predictionapi.MetricQuery{
+				MetricName:      "up",
+				QueryConditions: []predictionapi.QueryCondition{{Key: "job", Operator: predictionapi.OperatorIn}},
+			},
+			wantErr: ErrEmptyValue,
+		},
+		{
+			name: "too many values",
+			query: predictionapi.MetricQuery{
+				MetricName:      "up",
+				QueryConditions: []predictionapi.QueryCondition{{Key: "job", Operator: predictionapi.OperatorEqual, Value: []string{"a", "b"}}},
+			},
+			wantErr: ErrTooManyValues,
+		},
+		{
+			name: "unsupported operator",
+			query: predictionapi.MetricQuery{
+				MetricName:      "up",
+				QueryConditions: []predictionapi.QueryCondition{{Key: "job", Operator: "like", Value: []string{"a"}}},
+			},
+			wantErr: ErrUnsupportedOperator,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(PromQLDialect, &tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownDialect(t *testing.T) {
+	if _, err := Render("influxql", &predictionapi.MetricQuery{MetricName: "up"}); !errors.Is(err, ErrUnknownDialect) {
+		t.Errorf("got %v, want %v", err, ErrUnknownDialect)
+	}
+}
@@ -0,0 +1,85 @@
+// Package query renders the backend-neutral MetricQuery of a PredictionMetric
+// to the query language of a metric backend.
+//
+// Backends plug their query language in as a Dialect registered by name. The
+// PromQL dialect is registered as "prometheus".
+package query
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var (
+	// ErrEmptyMetricName is returned for a query without metric name.
+	ErrEmptyMetricName = errors.New("metric name is empty")
+	// ErrInvalidMetricName is returned for a metric name the dialect can not express.
+	ErrInvalidMetricName = errors.New("invalid metric name")
+	// ErrInvalidLabelName is returned for a condition key the dialect can not express.
+	ErrInvalidLabelName = errors.New("invalid label name")
+	// ErrEmptyValue is returned for a condition without value.
+	ErrEmptyValue = errors.New("condition has no value")
+	// ErrTooManyValues is returned for a condition whose operator takes a single value but has several.
+	ErrTooManyValues = errors.New("condition has too many values")
+	// ErrUnsupportedOperator is returned for a condition operator the dialect does not support.
+	ErrUnsupportedOperator = errors.New("unsupported operator")
+	// ErrUnknownDialect is returned when rendering with a dialect that is not registered.
+	ErrUnknownDialect = errors.New("unknown dialect")
+)
+
+// Dialect renders metric queries to the query language of a backend.
+type Dialect interface {
+	// Render renders query.
+	Render(query *predictionapi.MetricQuery) (string, error)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{}
+)
+
+// Register registers dialect under name. It panics if name is already registered.
+func Register(name string, dialect Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	if dialect == nil {
+		panic("query: Register dialect is nil")
+	}
+	if _, dup := dialects[name]; dup {
+		panic("query: Register called twice for dialect " + name)
+	}
+	dialects[name] = dialect
+}
+
+// Lookup returns the dialect registered under name.
+func Lookup(name string) (Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	dialect, ok := dialects[name]
+	return dialect, ok
+}
+
+// Dialects returns the sorted names of the registered dialects.
+func Dialects() []string {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders query with the dialect registered under name.
+func Render(name string, query *predictionapi.MetricQuery) (string, error) {
+	dialect, ok := Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownDialect, name)
+	}
+	return dialect.Render(query)
+}
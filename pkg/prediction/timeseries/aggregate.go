@@ -0,0 +1,142 @@
+package timeseries
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// Aggregator reduces values to a single value. Aggregators skip NaN values,
+// and return NaN when no value is left.
+type Aggregator func(values []float64) float64
+
+// present returns the values that are not NaN.
+func present(values []float64) []float64 {
+	out := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Max returns the largest value.
+func Max(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range present(values) {
+		if math.IsNaN(result) || v > result {
+			result = v
+		}
+	}
+	return result
+}
+
+// Min returns the least value.
+func Min(values []float64) float64 {
+	result := math.NaN()
+	for _, v := range present(values) {
+		if math.IsNaN(result) || v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+// Sum returns the sum of the values.
+func Sum(values []float64) float64 {
+	values = present(values)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// Avg returns the mean of the values.
+func Avg(values []float64) float64 {
+	n := len(present(values))
+	if n == 0 {
+		return math.NaN()
+	}
+	return Sum(values) / float64(n)
+}
+
+// Last returns the last value.
+func Last(values []float64) float64 {
+	values = present(values)
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return values[len(values)-1]
+}
+
+// Percentile returns an Aggregator of the p-quantile of values, with p in [0, 1].
+// It interpolates linearly between the closest ranks, so Percentile(0) is Min,
+// Percentile(1) is Max and Percentile(0.5) is the median.
+func Percentile(p float64) Aggregator {
+	q := math.Max(0, math.Min(1, p))
+	return func(values []float64) float64 {
+		values = present(values)
+		if len(values) == 0 || math.IsNaN(p) {
+			return math.NaN()
+		}
+		sort.Float64s(values)
+		rank := q * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+	}
+}
+
+// Aggregate groups series by the values of the labels by, and aggregates the values of every
+// group at every timestamp with aggregate. The aggregated series carry the labels by, in their
+// order, and are sorted by their label values.
+func Aggregate(series []*Series, by []string, aggregate Aggregator) []*Series {
+	type group struct {
+		labels []predictionapi.Label
+		values map[int64][]float64
+	}
+	groups := map[string]*group{}
+	for _, s := range series {
+		labels := make([]predictionapi.Label, len(by))
+		keys := make([]string, len(by))
+		for i, name := range by {
+			labels[i] = predictionapi.Label{Name: name, Value: s.Label(name)}
+			keys[i] = labels[i].Value
+		}
+		// The separator is not valid UTF-8, so it does not appear in label values.
+		key := strings.Join(keys, "\xff")
+		g, ok := groups[key]
+		if !ok {
+			g = &group{labels: labels, values: map[int64][]float64{}}
+			groups[key] = g
+		}
+		for _, p := range s.Points {
+			g.values[p.Timestamp] = append(g.values[p.Timestamp], p.Value)
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	aggregated := make([]*Series, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		s := &Series{Labels: g.labels}
+		for timestamp, values := range g.values {
+			s.Points = append(s.Points, Point{Timestamp: timestamp, Value: aggregate(values)})
+		}
+		s.Points = normalize(s.Points)
+		aggregated = append(aggregated, s)
+	}
+	return aggregated
+}
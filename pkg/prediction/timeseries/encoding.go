@@ -0,0 +1,148 @@
+package timeseries
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// ErrInvalidCompactSamples is returned when CompactSamples cannot be decoded.
+var ErrInvalidCompactSamples = errors.New("invalid compact samples")
+
+// Compact returns series with its samples in the compact encoding, which must be in chronological
+// order. The step is the greatest common divisor of the intervals between the samples, and the
+// timestamps missing between them are encoded as missing samples, so Expand restores the samples
+// exactly. A series already in the compact encoding is returned as is, and so is a series whose
+// compact encoding would not be smaller than its samples, such as when a sample off the grid drops
+// the step to a second.
+func Compact(series *predictionapi.MetricTimeSeries) (*predictionapi.MetricTimeSeries, error) {
+	if series.Compact != nil {
+		return series, nil
+	}
+	compacted := &predictionapi.MetricTimeSeries{Labels: series.Labels}
+	if len(series.Samples) == 0 {
+		return compacted, nil
+	}
+
+	samples := append([]predictionapi.Sample(nil), series.Samples...)
+	for i, sample := range samples {
+		if strings.Contains(sample.Value, ",") || strings.TrimSpace(sample.Value) == "" {
+			return nil, fmt.Errorf("sample %d: value %q cannot be compacted", i, sample.Value)
+		}
+		if i > 0 && sample.Timestamp <= samples[i-1].Timestamp {
+			return nil, fmt.Errorf("sample %d: timestamp %d is not after %d", i, sample.Timestamp, samples[i-1].Timestamp)
+		}
+	}
+
+	start := samples[0].Timestamp
+	var step int64
+	for _, sample := range samples[1:] {
+		step = gcd(step, sample.Timestamp-start)
+	}
+
+	if compactSize(samples, step) >= samplesSize(samples) {
+		return series, nil
+	}
+
+	var values strings.Builder
+	next := start
+	for i, sample := range samples {
+		if i > 0 {
+			// One separator per step, leaving the values of missing samples empty.
+			values.WriteString(strings.Repeat(",", int((sample.Timestamp-next)/step)+1))
+		}
+		values.WriteString(sample.Value)
+		next = sample.Timestamp + step
+	}
+	compacted.Compact = &predictionapi.CompactSamples{Start: start, Step: step, Values: values.String()}
+	return compacted, nil
+}
+
+// Expand returns series with its samples decoded from the compact encoding, leaving out the
+// missing samples. A series not in the compact encoding is returned as is.
+func Expand(series *predictionapi.MetricTimeSeries) (*predictionapi.MetricTimeSeries, error) {
+	compact := series.Compact
+	if compact == nil {
+		return series, nil
+	}
+	expanded := &predictionapi.MetricTimeSeries{Labels: series.Labels}
+	if compact.Values == "" {
+		return expanded, nil
+	}
+	values := strings.Split(compact.Values, ",")
+	if len(values) > 1 && compact.Step <= 0 {
+		return nil, fmt.Errorf("%w: step %d with %d values", ErrInvalidCompactSamples, compact.Step, len(values))
+	}
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		expanded.Samples = append(expanded.Samples, predictionapi.Sample{Timestamp: compact.Start + int64(i)*compact.Step, Value: value})
+	}
+	return expanded, nil
+}
+
+// CompactList compacts every series of list.
+func CompactList(list predictionapi.MetricTimeSeriesList) (predictionapi.MetricTimeSeriesList, error) {
+	return mapList(list, Compact)
+}
+
+// ExpandList expands every series of list.
+func ExpandList(list predictionapi.MetricTimeSeriesList) (predictionapi.MetricTimeSeriesList, error) {
+	return mapList(list, Expand)
+}
+
+// Encode encodes the series of list in encoding, as in the status of a TimeSeriesPrediction.
+func Encode(list predictionapi.MetricTimeSeriesList, encoding predictionapi.SampleEncoding) (predictionapi.MetricTimeSeriesList, error) {
+	if encoding == predictionapi.SampleEncodingCompact {
+		return CompactList(list)
+	}
+	return ExpandList(list)
+}
+
+func mapList(list predictionapi.MetricTimeSeriesList, f func(*predictionapi.MetricTimeSeries) (*predictionapi.MetricTimeSeries, error)) (predictionapi.MetricTimeSeriesList, error) {
+	mapped := make(predictionapi.MetricTimeSeriesList, len(list))
+	for i, series := range list {
+		if series == nil {
+			continue
+		}
+		s, err := f(series)
+		if err != nil {
+			return nil, fmt.Errorf("series %d: %w", i, err)
+		}
+		mapped[i] = s
+	}
+	return mapped, nil
+}
+
+// compactSize returns the size of the values of the compact encoding of samples with step.
+func compactSize(samples []predictionapi.Sample, step int64) int64 {
+	size := int64(0)
+	if len(samples) > 1 {
+		// One separator per step.
+		size = (samples[len(samples)-1].Timestamp - samples[0].Timestamp) / step
+	}
+	for _, sample := range samples {
+		size += int64(len(sample.Value))
+	}
+	return size
+}
+
+// samplesSize returns the size of samples serialized in JSON.
+func samplesSize(samples []predictionapi.Sample) int64 {
+	size := int64(0)
+	for _, sample := range samples {
+		size += int64(len(`{"timestamp":,"value":""},`) + len(strconv.FormatInt(sample.Timestamp, 10)) + len(sample.Value))
+	}
+	return size
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,93 @@
+package timeseries
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gocrane/api/pkg/prediction/query"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// Matcher matches series by their labels against query conditions. A label missing
+// from a series matches like a label with an empty value, and regular expressions
+// are anchored, as in PromQL.
+type Matcher struct {
+	conditions []predictionapi.QueryCondition
+	regexps    []*regexp.Regexp
+}
+
+// NewMatcher compiles conditions. Like the PromQL dialect of package query, it rejects conditions
+// without value with query.ErrEmptyValue, and conditions with several values for an operator that
+// takes a single one with query.ErrTooManyValues.
+func NewMatcher(conditions []predictionapi.QueryCondition) (*Matcher, error) {
+	m := &Matcher{conditions: conditions, regexps: make([]*regexp.Regexp, len(conditions))}
+	for i, condition := range conditions {
+		if len(condition.Value) == 0 {
+			return nil, fmt.Errorf("%w: %s %s", query.ErrEmptyValue, condition.Key, condition.Operator)
+		}
+		switch condition.Operator {
+		case predictionapi.OperatorEqual, predictionapi.OperatorNotEqual,
+			predictionapi.OperatorRegexMatch, predictionapi.OperatorNotRegexMatch:
+			if len(condition.Value) > 1 {
+				return nil, fmt.Errorf("%w: %s %s takes a single value, got %d", query.ErrTooManyValues, condition.Key, condition.Operator, len(condition.Value))
+			}
+		case predictionapi.OperatorIn:
+		default:
+			return nil, fmt.Errorf("%w: %s %q", query.ErrUnsupportedOperator, condition.Key, condition.Operator)
+		}
+		if condition.Operator == predictionapi.OperatorRegexMatch || condition.Operator == predictionapi.OperatorNotRegexMatch {
+			re, err := regexp.Compile("^(?:" + condition.Value[0] + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("condition on %s: %w", condition.Key, err)
+			}
+			m.regexps[i] = re
+		}
+	}
+	return m, nil
+}
+
+// Matches reports whether labels match all the conditions of m.
+func (m *Matcher) Matches(labels []predictionapi.Label) bool {
+	for i, condition := range m.conditions {
+		value := ""
+		for _, l := range labels {
+			if l.Name == condition.Key {
+				value = l.Value
+				break
+			}
+		}
+		var matches bool
+		switch condition.Operator {
+		case predictionapi.OperatorEqual:
+			matches = value == condition.Value[0]
+		case predictionapi.OperatorNotEqual:
+			matches = value != condition.Value[0]
+		case predictionapi.OperatorIn:
+			for _, v := range condition.Value {
+				if value == v {
+					matches = true
+					break
+				}
+			}
+		case predictionapi.OperatorRegexMatch:
+			matches = m.regexps[i].MatchString(value)
+		case predictionapi.OperatorNotRegexMatch:
+			matches = !m.regexps[i].MatchString(value)
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the series matching m.
+func (m *Matcher) Filter(series []*Series) []*Series {
+	var matched []*Series
+	for _, s := range series {
+		if m.Matches(s.Labels) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,266 @@
+// Package timeseries computes over the MetricTimeSeries of predictions, so that every consumer
+// of a PredictionMetricStatus derives the same numbers from it.
+//
+// Sample values are parsed by ParseValue, which accepts the NaN and +Inf/-Inf spellings, and
+// formatted by FormatValue, which formats the shortest decimal that parses back to the same
+// float, so a parse and format round trip is lossless. Timestamps are seconds since the epoch.
+//
+// NaN marks a missing value: aggregations skip it, and gap filling replaces it.
+package timeseries
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+// ParseValue parses the value of a Sample.
+func ParseValue(value string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value %q: %w", value, err)
+	}
+	return v, nil
+}
+
+// FormatValue formats a value for a Sample.
+func FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Float returns the value of sample.
+func Float(sample predictionapi.Sample) (float64, error) {
+	return ParseValue(sample.Value)
+}
+
+// Floats returns the values of the samples of series in their order.
+func Floats(series *predictionapi.MetricTimeSeries) ([]float64, error) {
+	series, err := Expand(series)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, len(series.Samples))
+	for i, sample := range series.Samples {
+		v, err := Float(sample)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// Point is a parsed Sample.
+type Point struct {
+	Timestamp int64
+	Value     float64
+}
+
+// Series is a parsed MetricTimeSeries whose points are sorted by timestamp, with unique timestamps.
+type Series struct {
+	Labels []predictionapi.Label
+	Points []Point
+}
+
+// FromMetricTimeSeries parses series, in either encoding. Its samples are sorted by timestamp,
+// and of several samples with the same timestamp the last one wins.
+func FromMetricTimeSeries(series *predictionapi.MetricTimeSeries) (*Series, error) {
+	series, err := Expand(series)
+	if err != nil {
+		return nil, err
+	}
+	s := &Series{Labels: append([]predictionapi.Label(nil), series.Labels...)}
+	for _, sample := range series.Samples {
+		v, err := Float(sample)
+		if err != nil {
+			return nil, err
+		}
+		s.Points = append(s.Points, Point{Timestamp: sample.Timestamp, Value: v})
+	}
+	s.Points = normalize(s.Points)
+	return s, nil
+}
+
+// FromMetricTimeSeriesList parses list.
+func FromMetricTimeSeriesList(list predictionapi.MetricTimeSeriesList) ([]*Series, error) {
+	series := make([]*Series, 0, len(list))
+	for i, s := range list {
+		if s == nil {
+			continue
+		}
+		parsed, err := FromMetricTimeSeries(s)
+		if err != nil {
+			return nil, fmt.Errorf("series %d: %w", i, err)
+		}
+		series = append(series, parsed)
+	}
+	return series, nil
+}
+
+// ToMetricTimeSeries formats s.
+func (s *Series) ToMetricTimeSeries() *predictionapi.MetricTimeSeries {
+	series := &predictionapi.MetricTimeSeries{Labels: append([]predictionapi.Label(nil), s.Labels...)}
+	for _, p := range s.Points {
+		series.Samples = append(series.Samples, predictionapi.Sample{Timestamp: p.Timestamp, Value: FormatValue(p.Value)})
+	}
+	return series
+}
+
+// ToMetricTimeSeriesList formats series.
+func ToMetricTimeSeriesList(series []*Series) predictionapi.MetricTimeSeriesList {
+	list := make(predictionapi.MetricTimeSeriesList, len(series))
+	for i, s := range series {
+		list[i] = s.ToMetricTimeSeries()
+	}
+	return list
+}
+
+// Values returns the values of the points of s.
+func (s *Series) Values() []float64 {
+	values := make([]float64, len(s.Points))
+	for i, p := range s.Points {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// Label returns the value of the label name of s, empty if s has no such label.
+func (s *Series) Label(name string) string {
+	for _, l := range s.Labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// At returns the value of the point of s at timestamp.
+func (s *Series) At(timestamp int64) (float64, bool) {
+	i := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].Timestamp >= timestamp })
+	if i < len(s.Points) && s.Points[i].Timestamp == timestamp {
+		return s.Points[i].Value, true
+	}
+	return math.NaN(), false
+}
+
+// Interpolate returns the value of s at timestamp, interpolated linearly between the points of s
+// around it when no point of s is at timestamp. It fails outside of the points of s, and next to
+// a NaN value.
+func (s *Series) Interpolate(timestamp int64) (float64, bool) {
+	i := sort.Search(len(s.Points), func(i int) bool { return s.Points[i].Timestamp >= timestamp })
+	if i == len(s.Points) {
+		return math.NaN(), false
+	}
+	next := s.Points[i]
+	if next.Timestamp == timestamp {
+		return next.Value, !math.IsNaN(next.Value)
+	}
+	if i == 0 {
+		return math.NaN(), false
+	}
+	previous := s.Points[i-1]
+	if math.IsNaN(previous.Value) || math.IsNaN(next.Value) {
+		return math.NaN(), false
+	}
+	slope := (next.Value - previous.Value) / float64(next.Timestamp-previous.Timestamp)
+	return previous.Value + slope*float64(timestamp-previous.Timestamp), true
+}
+
+// normalize sorts points by timestamp, keeping the last of points with the same timestamp.
+func normalize(points []Point) []Point {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Timestamp < points[j].Timestamp })
+	out := points[:0]
+	for _, p := range points {
+		if n := len(out); n > 0 && out[n-1].Timestamp == p.Timestamp {
+			out[n-1] = p
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Instant reduces s to its maximum over the window [start, end), in seconds since the epoch.
+// The value is NaN when s has no point in the window.
+func Instant(s *Series, start, end int64) predictionapi.InstantPrediction {
+	return predictionapi.InstantPrediction{
+		Labels:      append([]predictionapi.Label(nil), s.Labels...),
+		Value:       FormatValue(windowMax(s, start, end)),
+		WindowStart: start,
+		WindowEnd:   end,
+	}
+}
+
+// InstantPredictions reduces every series of list to its maximum over the window [start, end),
+// the status of a metric predicted in PredictionModeInstant.
+func InstantPredictions(list predictionapi.MetricTimeSeriesList, start, end int64) ([]predictionapi.InstantPrediction, error) {
+	series, err := FromMetricTimeSeriesList(list)
+	if err != nil {
+		return nil, err
+	}
+	instants := make([]predictionapi.InstantPrediction, len(series))
+	for i, s := range series {
+		instants[i] = Instant(s, start, end)
+	}
+	return instants, nil
+}
+
+// InstantBounds sets the UpperBound and LowerBound of every instant to the maximum over its window
+// of the upper and lower bound series with the same labels. A bound without such a series is NaN.
+func InstantBounds(instants []predictionapi.InstantPrediction, upper, lower predictionapi.MetricTimeSeriesList) error {
+	upperSeries, err := seriesByLabels(upper)
+	if err != nil {
+		return fmt.Errorf("upper bound: %w", err)
+	}
+	lowerSeries, err := seriesByLabels(lower)
+	if err != nil {
+		return fmt.Errorf("lower bound: %w", err)
+	}
+	for i := range instants {
+		instant := &instants[i]
+		key := LabelsKey(instant.Labels)
+		instant.UpperBound = FormatValue(windowMax(upperSeries[key], instant.WindowStart, instant.WindowEnd))
+		instant.LowerBound = FormatValue(windowMax(lowerSeries[key], instant.WindowStart, instant.WindowEnd))
+	}
+	return nil
+}
+
+// windowMax returns the maximum of the points of s in the window [start, end), NaN if there is none.
+func windowMax(s *Series, start, end int64) float64 {
+	if s == nil {
+		return math.NaN()
+	}
+	var values []float64
+	for _, p := range s.Points {
+		if p.Timestamp >= start && p.Timestamp < end {
+			values = append(values, p.Value)
+		}
+	}
+	return Max(values)
+}
+
+func seriesByLabels(list predictionapi.MetricTimeSeriesList) (map[string]*Series, error) {
+	series, err := FromMetricTimeSeriesList(list)
+	if err != nil {
+		return nil, err
+	}
+	byLabels := make(map[string]*Series, len(series))
+	for _, s := range series {
+		byLabels[LabelsKey(s.Labels)] = s
+	}
+	return byLabels, nil
+}
+
+// LabelsKey identifies a set of labels regardless of their order, to match series by labels.
+func LabelsKey(labels []predictionapi.Label) string {
+	pairs := make([]string, len(labels))
+	for i, l := range labels {
+		pairs[i] = strconv.Quote(l.Name) + "=" + strconv.Quote(l.Value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,343 @@
+package timeseries
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gocrane/api/pkg/prediction/query"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func newSeries(labels map[string]string, points ...Point) *Series {
+	s := &Series{Points: points}
+	for _, name := range []string{"container", "namespace", "pod"} {
+		if value, ok := labels[name]; ok {
+			s.Labels = append(s.Labels, predictionapi.Label{Name: name, Value: value})
+		}
+	}
+	return s
+}
+
+// equalPoints compares points, with NaN equal to NaN.
+func equalPoints(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Timestamp != b[i].Timestamp {
+			return false
+		}
+		if a[i].Value != b[i].Value && !(math.IsNaN(a[i].Value) && math.IsNaN(b[i].Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := &predictionapi.MetricTimeSeries{
+		Labels: []predictionapi.Label{{Name: "pod", Value: "web-0"}},
+		Samples: []predictionapi.Sample{
+			{Timestamp: 120, Value: "0.1"},
+			{Timestamp: 60, Value: "NaN"},
+			{Timestamp: 180, Value: " +Inf"},
+			{Timestamp: 120, Value: "1e-3"},
+		},
+	}
+	s, err := FromMetricTimeSeries(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Point{{60, math.NaN()}, {120, 0.001}, {180, math.Inf(1)}}
+	if !equalPoints(s.Points, want) {
+		t.Errorf("got %v, want %v", s.Points, want)
+	}
+
+	out := s.ToMetricTimeSeries()
+	wantSamples := []predictionapi.Sample{{Timestamp: 60, Value: "NaN"}, {Timestamp: 120, Value: "0.001"}, {Timestamp: 180, Value: "+Inf"}}
+	if !reflect.DeepEqual(out.Samples, wantSamples) || !reflect.DeepEqual(out.Labels, in.Labels) {
+		t.Errorf("got %v, want %v", out, wantSamples)
+	}
+
+	if _, err := Floats(&predictionapi.MetricTimeSeries{Samples: []predictionapi.Sample{{Value: ""}}}); err == nil {
+		t.Error("expected an error for an empty value")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	s := newSeries(nil, Point{Timestamp: 0, Value: 0}, Point{Timestamp: 60, Value: 6}, Point{Timestamp: 120, Value: math.NaN()})
+	tests := []struct {
+		timestamp int64
+		want      float64
+		wantOK    bool
+	}{
+		{timestamp: -1},
+		{timestamp: 0, want: 0, wantOK: true},
+		{timestamp: 15, want: 1.5, wantOK: true},
+		{timestamp: 60, want: 6, wantOK: true},
+		{timestamp: 90},
+		{timestamp: 180},
+	}
+	for _, tt := range tests {
+		got, ok := s.Interpolate(tt.timestamp)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Interpolate(%d) = %v, %v, want %v, %v", tt.timestamp, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestAggregators(t *testing.T) {
+	values := []float64{4, math.NaN(), 1, 3, 2}
+	tests := []struct {
+		name       string
+		aggregator Aggregator
+		want       float64
+	}{
+		{"max", Max, 4},
+		{"min", Min, 1},
+		{"sum", Sum, 10},
+		{"avg", Avg, 2.5},
+		{"last", Last, 2},
+		{"median", Percentile(0.5), 2.5},
+		{"p90", Percentile(0.9), 3.7},
+		{"p100", Percentile(1), 4},
+	}
+	for _, tt := range tests {
+		if got := tt.aggregator(values); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.aggregator([]float64{math.NaN()}); !math.IsNaN(got) {
+			t.Errorf("%s: got %v for no value, want NaN", tt.name, got)
+		}
+	}
+}
+
+func TestResampleAndFillGaps(t *testing.T) {
+	s := newSeries(nil, Point{0, 1}, Point{20, 3}, Point{70, 2}, Point{130, 8}, Point{150, math.NaN()})
+
+	resampled := Resample(s, time.Minute, Max)
+	if want := []Point{{0, 3}, {60, 2}, {120, 8}}; !equalPoints(resampled.Points, want) {
+		t.Errorf("got %v, want %v", resampled.Points, want)
+	}
+
+	sparse := newSeries(nil, Point{0, 1}, Point{60, math.NaN()}, Point{180, 7})
+	tests := []struct {
+		fill Fill
+		want []Point
+	}{
+		{FillNaN, []Point{{0, 1}, {60, math.NaN()}, {120, math.NaN()}, {180, 7}}},
+		{FillZero, []Point{{0, 1}, {60, 0}, {120, 0}, {180, 7}}},
+		{FillPrevious, []Point{{0, 1}, {60, 1}, {120, 1}, {180, 7}}},
+		{FillLinear, []Point{{0, 1}, {60, 3}, {120, 5}, {180, 7}}},
+	}
+	for _, tt := range tests {
+		if got := FillGaps(sparse, time.Minute, tt.fill); !equalPoints(got.Points, tt.want) {
+			t.Errorf("fill %d: got %v, want %v", tt.fill, got.Points, tt.want)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	a := newSeries(nil, Point{0, 1}, Point{60, 2})
+	b := newSeries(nil, Point{65, 5}, Point{130, 6})
+
+	aligned := Align([]*Series{a, b}, time.Minute, Last)
+	if want := []Point{{0, 1}, {60, 2}, {120, math.NaN()}}; !equalPoints(aligned[0].Points, want) {
+		t.Errorf("got %v, want %v", aligned[0].Points, want)
+	}
+	if want := []Point{{0, math.NaN()}, {60, 5}, {120, 6}}; !equalPoints(aligned[1].Points, want) {
+		t.Errorf("got %v, want %v", aligned[1].Points, want)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	series := []*Series{
+		newSeries(map[string]string{"namespace": "b", "pod": "b-0"}, Point{0, 1}, Point{60, 4}),
+		newSeries(map[string]string{"namespace": "a", "pod": "a-0"}, Point{0, 2}),
+		newSeries(map[string]string{"namespace": "a", "pod": "a-1"}, Point{0, 3}, Point{60, 1}),
+	}
+
+	aggregated := Aggregate(series, []string{"namespace"}, Sum)
+	if len(aggregated) != 2 {
+		t.Fatalf("got %d series, want 2", len(aggregated))
+	}
+	if got := aggregated[0].Label("namespace"); got != "a" {
+		t.Errorf("got namespace %q first, want a", got)
+	}
+	if want := []Point{{0, 5}, {60, 1}}; !equalPoints(aggregated[0].Points, want) {
+		t.Errorf("got %v, want %v", aggregated[0].Points, want)
+	}
+
+	overall := Aggregate(series, nil, Max)
+	if want := []Point{{0, 3}, {60, 4}}; len(overall) != 1 || !equalPoints(overall[0].Points, want) {
+		t.Errorf("got %v, want a single series of %v", overall, want)
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	series := []*Series{
+		newSeries(map[string]string{"namespace": "default", "pod": "web-0", "container": "app"}),
+		newSeries(map[string]string{"namespace": "default", "pod": "web-1"}),
+		newSeries(map[string]string{"namespace": "kube-system", "pod": "dns-0", "container": "app"}),
+	}
+
+	tests := []struct {
+		name       string
+		conditions []predictionapi.QueryCondition
+		want       []*Series
+	}{
+		{
+			name:       "equal",
+			conditions: []predictionapi.QueryCondition{{Key: "namespace", Operator: predictionapi.OperatorEqual, Value: []string{"default"}}},
+			want:       series[:2],
+		},
+		{
+			name:       "missing label is empty",
+			conditions: []predictionapi.QueryCondition{{Key: "container", Operator: predictionapi.OperatorNotEqual, Value: []string{""}}},
+			want:       []*Series{series[0], series[2]},
+		},
+		{
+			name:       "anchored regexp",
+			conditions: []predictionapi.QueryCondition{{Key: "pod", Operator: predictionapi.OperatorRegexMatch, Value: []string{"web"}}},
+		},
+		{
+			name: "in and not regexp",
+			conditions: []predictionapi.QueryCondition{
+				{Key: "namespace", Operator: predictionapi.OperatorIn, Value: []string{"default", "kube-system"}},
+				{Key: "pod", Operator: predictionapi.OperatorNotRegexMatch, Value: []string{"web-.*"}},
+			},
+			want: series[2:],
+		},
+	}
+	for _, tt := range tests {
+		m, err := NewMatcher(tt.conditions)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := m.Filter(series); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := NewMatcher([]predictionapi.QueryCondition{{Key: "pod", Operator: predictionapi.OperatorRegexMatch, Value: []string{"("}}}); err == nil {
+		t.Error("expected an error for an invalid regexp")
+	}
+	for _, operator := range []predictionapi.Operator{predictionapi.OperatorEqual, predictionapi.OperatorNotEqual, predictionapi.OperatorRegexMatch, predictionapi.OperatorNotRegexMatch} {
+		conditions := []predictionapi.QueryCondition{{Key: "pod", Operator: operator, Value: []string{"web-0", "web-1"}}}
+		if _, err := NewMatcher(conditions); !errors.Is(err, query.ErrTooManyValues) {
+			t.Errorf("got %v for several values of %s, want %v", err, operator, query.ErrTooManyValues)
+		}
+	}
+}
+
+func TestInstantPredictions(t *testing.T) {
+	list := predictionapi.MetricTimeSeriesList{
+		{
+			Labels:  []predictionapi.Label{{Name: "pod", Value: "web-0"}},
+			Samples: []predictionapi.Sample{{Timestamp: 0, Value: "9"}, {Timestamp: 60, Value: "2"}, {Timestamp: 120, Value: "3"}, {Timestamp: 3660, Value: "8"}},
+		},
+		{
+			Labels: []predictionapi.Label{{Name: "pod", Value: "web-1"}},
+		},
+	}
+
+	got, err := InstantPredictions(list, 60, 3660)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []predictionapi.InstantPrediction{
+		{Labels: list[0].Labels, Value: "3", WindowStart: 60, WindowEnd: 3660},
+		{Labels: list[1].Labels, Value: "NaN", WindowStart: 60, WindowEnd: 3660},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInstantBounds(t *testing.T) {
+	labels := []predictionapi.Label{{Name: "pod", Value: "web-0"}, {Name: "namespace", Value: "default"}}
+	instants := []predictionapi.InstantPrediction{
+		{Labels: labels, Value: "3", WindowStart: 60, WindowEnd: 180},
+		{Labels: []predictionapi.Label{{Name: "pod", Value: "web-1"}}, Value: "1", WindowStart: 60, WindowEnd: 180},
+	}
+	upper := predictionapi.MetricTimeSeriesList{{
+		Labels:  []predictionapi.Label{labels[1], labels[0]},
+		Samples: []predictionapi.Sample{{Timestamp: 0, Value: "9"}, {Timestamp: 60, Value: "4"}, {Timestamp: 120, Value: "5"}},
+	}}
+	lower := predictionapi.MetricTimeSeriesList{{
+		Labels:  labels,
+		Samples: []predictionapi.Sample{{Timestamp: 60, Value: "2"}, {Timestamp: 120, Value: "1"}, {Timestamp: 180, Value: "7"}},
+	}}
+
+	if err := InstantBounds(instants, upper, lower); err != nil {
+		t.Fatal(err)
+	}
+	if instants[0].UpperBound != "5" || instants[0].LowerBound != "2" {
+		t.Errorf("got bounds [%s, %s], want [2, 5]", instants[0].LowerBound, instants[0].UpperBound)
+	}
+	if instants[1].UpperBound != "NaN" || instants[1].LowerBound != "NaN" {
+		t.Errorf("got bounds [%s, %s] without bound series, want NaN", instants[1].LowerBound, instants[1].UpperBound)
+	}
+}
+
+func TestCompactAndExpand(t *testing.T) {
+	in := &predictionapi.MetricTimeSeries{
+		Labels:  []predictionapi.Label{{Name: "pod", Value: "web-0"}},
+		Samples: []predictionapi.Sample{{Timestamp: 60, Value: "1.5"}, {Timestamp: 120, Value: "NaN"}, {Timestamp: 300, Value: "2"}, {Timestamp: 330, Value: "3"}},
+	}
+
+	compacted, err := Compact(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &predictionapi.CompactSamples{Start: 60, Step: 30, Values: "1.5,,NaN,,,,,,2,3"}
+	if !reflect.DeepEqual(compacted.Compact, want) || compacted.Samples != nil {
+		t.Errorf("got %+v, want %+v", compacted.Compact, want)
+	}
+
+	expanded, err := Expand(compacted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expanded, in) {
+		t.Errorf("got %+v after a round trip, want %+v", expanded, in)
+	}
+
+	values, err := Floats(compacted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 4 || values[3] != 3 {
+		t.Errorf("got values %v of the compact series, want 4 values ending with 3", values)
+	}
+
+	if _, err := Compact(&predictionapi.MetricTimeSeries{Samples: []predictionapi.Sample{{Timestamp: 60, Value: "1"}, {Timestamp: 0, Value: "2"}}}); err == nil {
+		t.Error("expected an error for samples out of order")
+	}
+	if _, err := Expand(&predictionapi.MetricTimeSeries{Compact: &predictionapi.CompactSamples{Values: "1,2"}}); err == nil {
+		t.Error("expected an error for a zero step")
+	}
+}
+
+func TestCompactMisaligned(t *testing.T) {
+	// A week of samples every minute, one of them a second off the grid.
+	in := &predictionapi.MetricTimeSeries{}
+	for timestamp := int64(60); timestamp <= 7*24*3600; timestamp += 60 {
+		sample := predictionapi.Sample{Timestamp: timestamp, Value: "1"}
+		if timestamp == 180 {
+			sample.Timestamp++
+		}
+		in.Samples = append(in.Samples, sample)
+	}
+
+	compacted, err := Compact(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compacted.Compact != nil || !reflect.DeepEqual(compacted.Samples, in.Samples) {
+		t.Errorf("got compact samples %+v, want the samples kept as the compact encoding is not smaller", compacted.Compact)
+	}
+}
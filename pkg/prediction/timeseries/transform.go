@@ -0,0 +1,163 @@
+package timeseries
+
+import (
+	"math"
+	"time"
+)
+
+// stepSeconds returns step in seconds, at least 1.
+func stepSeconds(step time.Duration) int64 {
+	if seconds := int64(step / time.Second); seconds > 0 {
+		return seconds
+	}
+	return 1
+}
+
+// bucket returns the start of the step that timestamp falls in.
+func bucket(timestamp, step int64) int64 {
+	b := timestamp - timestamp%step
+	if timestamp < 0 && timestamp%step != 0 {
+		b -= step
+	}
+	return b
+}
+
+// Resample aggregates the points of s in buckets of step, aligned on multiples of step since the epoch.
+// Every bucket with points yields a point at the start of the bucket; empty buckets yield none,
+// FillGaps inserts them.
+func Resample(s *Series, step time.Duration, aggregate Aggregator) *Series {
+	seconds := stepSeconds(step)
+	resampled := &Series{Labels: s.Labels}
+	for i := 0; i < len(s.Points); {
+		b := bucket(s.Points[i].Timestamp, seconds)
+		var values []float64
+		for ; i < len(s.Points) && bucket(s.Points[i].Timestamp, seconds) == b; i++ {
+			values = append(values, s.Points[i].Value)
+		}
+		resampled.Points = append(resampled.Points, Point{Timestamp: b, Value: aggregate(values)})
+	}
+	return resampled
+}
+
+// Fill is a strategy to fill missing values.
+type Fill int
+
+const (
+	// FillNaN leaves missing values NaN.
+	FillNaN Fill = iota
+	// FillZero replaces missing values with zero.
+	FillZero
+	// FillPrevious replaces missing values with the previous present value.
+	// Missing values before the first present value stay NaN.
+	FillPrevious
+	// FillLinear interpolates missing values linearly between the surrounding present values.
+	// Missing values before the first or after the last present value stay NaN.
+	FillLinear
+)
+
+// FillGaps returns s with a point at every step from its first to its last point, and with its NaN values
+// and the values of the inserted points filled by fill. The points of s are expected on a grid of step,
+// as returned by Resample.
+func FillGaps(s *Series, step time.Duration, fill Fill) *Series {
+	filled := &Series{Labels: s.Labels}
+	if len(s.Points) == 0 {
+		return filled
+	}
+
+	seconds := stepSeconds(step)
+	last := s.Points[len(s.Points)-1].Timestamp
+	i := 0
+	for t := s.Points[0].Timestamp; t <= last; t += seconds {
+		// Keep the points off the grid, in order.
+		for ; i < len(s.Points) && s.Points[i].Timestamp < t; i++ {
+			filled.Points = append(filled.Points, s.Points[i])
+		}
+		if i < len(s.Points) && s.Points[i].Timestamp == t {
+			filled.Points = append(filled.Points, s.Points[i])
+			i++
+			continue
+		}
+		filled.Points = append(filled.Points, Point{Timestamp: t, Value: math.NaN()})
+	}
+	filled.Points = append(filled.Points, s.Points[i:]...)
+
+	fillValues(filled.Points, fill)
+	return filled
+}
+
+// fillValues fills the NaN values of points in place.
+func fillValues(points []Point, fill Fill) {
+	switch fill {
+	case FillZero:
+		for i := range points {
+			if math.IsNaN(points[i].Value) {
+				points[i].Value = 0
+			}
+		}
+	case FillPrevious:
+		previous := math.NaN()
+		for i := range points {
+			if math.IsNaN(points[i].Value) {
+				points[i].Value = previous
+			} else {
+				previous = points[i].Value
+			}
+		}
+	case FillLinear:
+		previous := -1
+		for i := range points {
+			if math.IsNaN(points[i].Value) {
+				continue
+			}
+			if previous >= 0 && i-previous > 1 {
+				from, to := points[previous], points[i]
+				slope := (to.Value - from.Value) / float64(to.Timestamp-from.Timestamp)
+				for j := previous + 1; j < i; j++ {
+					points[j].Value = from.Value + slope*float64(points[j].Timestamp-from.Timestamp)
+				}
+			}
+			previous = i
+		}
+	}
+}
+
+// Align resamples series to step with aggregate and gives them the same timestamps: every step from the
+// earliest to the latest point of any of them. The values missing from a series are NaN.
+func Align(series []*Series, step time.Duration, aggregate Aggregator) []*Series {
+	seconds := stepSeconds(step)
+	resampled := make([]*Series, len(series))
+	var from, until int64
+	found := false
+	for i, s := range series {
+		resampled[i] = Resample(s, step, aggregate)
+		points := resampled[i].Points
+		if len(points) == 0 {
+			continue
+		}
+		if first := points[0].Timestamp; !found || first < from {
+			from = first
+		}
+		if last := points[len(points)-1].Timestamp; !found || last > until {
+			until = last
+		}
+		found = true
+	}
+
+	aligned := make([]*Series, len(series))
+	for i, s := range resampled {
+		aligned[i] = &Series{Labels: s.Labels}
+		if !found {
+			continue
+		}
+		j := 0
+		for t := from; t <= until; t += seconds {
+			value := math.NaN()
+			if j < len(s.Points) && s.Points[j].Timestamp == t {
+				value = s.Points[j].Value
+				j++
+			}
+			aligned[i].Points = append(aligned[i].Points, Point{Timestamp: t, Value: value})
+		}
+	}
+	return aligned
+}
@@ -0,0 +1,452 @@
+// Package validation validates the objects of the prediction API group.
+package validation
+
+import (
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane/api/pkg/prediction/clusternode"
+	"github.com/gocrane/api/pkg/util/duration"
+	utilvalidation "github.com/gocrane/api/pkg/util/validation"
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+var (
+	supportedAlgorithmTypes = sets.NewString(
+		string(predictionapi.AlgorithmTypePercentile),
+		string(predictionapi.AlgorithmTypeDSP),
+		string(predictionapi.AlgorithmTypeLinear),
+		string(predictionapi.AlgorithmTypeHoltWinters),
+	)
+	supportedMetricTypes = sets.NewString(
+		string(predictionapi.ResourceQueryMetricType),
+		string(predictionapi.MetricQueryMetricType),
+		string(predictionapi.ExpressionQueryMetricType),
+	)
+	supportedResourceQueries = sets.NewString(
+		string(v1.ResourceCPU),
+		string(v1.ResourceMemory),
+	)
+	supportedOperators = sets.NewString(
+		string(predictionapi.OperatorEqual),
+		string(predictionapi.OperatorNotEqual),
+		string(predictionapi.OperatorRegexMatch),
+		string(predictionapi.OperatorNotRegexMatch),
+		string(predictionapi.OperatorIn),
+	)
+	supportedModes = sets.NewString(
+		string(predictionapi.PredictionModeInstant),
+		string(predictionapi.PredictionModeRange),
+	)
+	supportedSampleEncodings = sets.NewString(
+		string(predictionapi.SampleEncodingSamples),
+		string(predictionapi.SampleEncodingCompact),
+	)
+)
+
+// ValidateTimeSeriesPrediction validates a TimeSeriesPrediction.
+func ValidateTimeSeriesPrediction(tsp *predictionapi.TimeSeriesPrediction) field.ErrorList {
+	return ValidateTimeSeriesPredictionSpec(&tsp.Spec, field.NewPath("spec"))
+}
+
+// ValidateTimeSeriesPredictionSpec validates a TimeSeriesPredictionSpec.
+func ValidateTimeSeriesPredictionSpec(spec *predictionapi.TimeSeriesPredictionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.TargetRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "kind"), ""))
+	}
+	if spec.TargetRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "name"), ""))
+	}
+	if spec.PredictionWindowSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("predictionWindowSeconds"), spec.PredictionWindowSeconds, "must be greater than or equal to 0"))
+	}
+
+	identifiers := sets.NewString()
+	for i := range spec.PredictionMetrics {
+		idxPath := fldPath.Child("predictionMetrics").Index(i)
+		metric := &spec.PredictionMetrics[i]
+		if identifiers.Has(metric.ResourceIdentifier) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("resourceIdentifier"), metric.ResourceIdentifier))
+		}
+		identifiers.Insert(metric.ResourceIdentifier)
+		allErrs = append(allErrs, ValidatePredictionMetric(metric, idxPath)...)
+	}
+	if spec.SampleEncoding != "" && !supportedSampleEncodings.Has(string(spec.SampleEncoding)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sampleEncoding"), spec.SampleEncoding, supportedSampleEncodings.List()))
+	}
+	if spec.AccuracyPolicy != nil {
+		allErrs = append(allErrs, ValidateAccuracyPolicy(spec.AccuracyPolicy, fldPath.Child("accuracyPolicy"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateAccuracyPolicy validates an AccuracyPolicy.
+func ValidateAccuracyPolicy(policy *predictionapi.AccuracyPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.WindowSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("windowSeconds"), policy.WindowSeconds, "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(policy.MAPEThreshold, fldPath.Child("mapeThreshold"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(policy.RMSEThreshold, fldPath.Child("rmseThreshold"))...)
+
+	return allErrs
+}
+
+// ValidatePredictionMetric validates a PredictionMetric. The query matching Type must be set.
+func ValidatePredictionMetric(metric *predictionapi.PredictionMetric, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if metric.ResourceIdentifier == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceIdentifier"), ""))
+	}
+
+	switch metric.Type {
+	case predictionapi.ResourceQueryMetricType:
+		if metric.ResourceQuery == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("resourceQuery"), fmt.Sprintf("must be set when type is %s", metric.Type)))
+		} else if !supportedResourceQueries.Has(string(*metric.ResourceQuery)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("resourceQuery"), *metric.ResourceQuery, supportedResourceQueries.List()))
+		}
+	case predictionapi.MetricQueryMetricType:
+		if metric.MetricQuery == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("metricQuery"), fmt.Sprintf("must be set when type is %s", metric.Type)))
+		} else {
+			allErrs = append(allErrs, ValidateMetricQuery(metric.MetricQuery, fldPath.Child("metricQuery"))...)
+		}
+	case predictionapi.ExpressionQueryMetricType:
+		if metric.ExpressionQuery == nil || metric.ExpressionQuery.Expression == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("expressionQuery", "expression"), fmt.Sprintf("must be set when type is %s", metric.Type)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), metric.Type, supportedMetricTypes.List()))
+	}
+
+	allErrs = append(allErrs, ValidateAlgorithm(&metric.Algorithm, fldPath.Child("algorithm"))...)
+	allErrs = append(allErrs, validateMode(metric.Mode, fldPath.Child("mode"))...)
+	return allErrs
+}
+
+// ValidateMetricQuery validates a MetricQuery.
+func ValidateMetricQuery(query *predictionapi.MetricQuery, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if query.MetricName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("metricName"), ""))
+	}
+	for i, condition := range query.QueryConditions {
+		idxPath := fldPath.Child("labels").Index(i)
+		if condition.Key == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("key"), ""))
+		}
+		if !supportedOperators.Has(string(condition.Operator)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("operator"), condition.Operator, supportedOperators.List()))
+		}
+		if len(condition.Value) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("value"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateAlgorithm validates an Algorithm. The algorithm type is required,
+// while its config may be left empty to use the defaults of the algorithm.
+func ValidateAlgorithm(algorithm *predictionapi.Algorithm, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if algorithm.AlgorithmType == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("algorithmType"), ""))
+	} else if !supportedAlgorithmTypes.Has(string(algorithm.AlgorithmType)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("algorithmType"), algorithm.AlgorithmType, supportedAlgorithmTypes.List()))
+	}
+	if algorithm.DSP != nil {
+		allErrs = append(allErrs, ValidateDSP(algorithm.DSP, fldPath.Child("dsp"))...)
+	}
+	if algorithm.Percentile != nil {
+		allErrs = append(allErrs, ValidatePercentile(algorithm.Percentile, fldPath.Child("percentile"))...)
+	}
+	if algorithm.Linear != nil {
+		allErrs = append(allErrs, ValidateLinear(algorithm.Linear, fldPath.Child("linear"))...)
+	}
+	if algorithm.HoltWinters != nil {
+		allErrs = append(allErrs, ValidateHoltWinters(algorithm.HoltWinters, fldPath.Child("holtWinters"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateDSP validates a DSP config.
+func ValidateDSP(dsp *predictionapi.DSP, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, utilvalidation.ValidateInterval(dsp.SampleInterval, fldPath.Child("sampleInterval"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(dsp.HistoryLength, fldPath.Child("historyLength"))...)
+
+	for i, estimator := range dsp.Estimators.MaxValueEstimators {
+		if estimator == nil {
+			continue
+		}
+		idxPath := fldPath.Child("estimators", "maxValue").Index(i)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(estimator.MarginFraction, idxPath.Child("marginFraction"))...)
+	}
+	for i, estimator := range dsp.Estimators.FFTEstimators {
+		if estimator == nil {
+			continue
+		}
+		idxPath := fldPath.Child("estimators", "fft").Index(i)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(estimator.MarginFraction, idxPath.Child("marginFraction"))...)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(estimator.LowAmplitudeThreshold, idxPath.Child("lowAmplitudeThreshold"))...)
+		allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(estimator.HighFrequencyThreshold, idxPath.Child("highFrequencyThreshold"))...)
+		if estimator.MinNumOfSpectrumItems != nil && *estimator.MinNumOfSpectrumItems < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("minNumOfSpectrumItems"), *estimator.MinNumOfSpectrumItems, "must be greater than or equal to 0"))
+		}
+		if estimator.MaxNumOfSpectrumItems != nil && *estimator.MaxNumOfSpectrumItems < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxNumOfSpectrumItems"), *estimator.MaxNumOfSpectrumItems, "must be greater than or equal to 0"))
+		}
+		if estimator.MinNumOfSpectrumItems != nil && estimator.MaxNumOfSpectrumItems != nil &&
+			*estimator.MinNumOfSpectrumItems > *estimator.MaxNumOfSpectrumItems {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("minNumOfSpectrumItems"), *estimator.MinNumOfSpectrumItems, "must be less than or equal to maxNumOfSpectrumItems"))
+		}
+	}
+	if dsp.Bounds != nil {
+		allErrs = append(allErrs, ValidateBounds(dsp.Bounds, fldPath.Child("bounds"))...)
+	}
+
+	return allErrs
+}
+
+// ValidatePercentile validates a Percentile config.
+func ValidatePercentile(percentile *predictionapi.Percentile, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(percentile.HistoryLength, fldPath.Child("historyLength"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateInterval(percentile.SampleInterval, fldPath.Child("sampleInterval"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(percentile.MinSampleWeight, fldPath.Child("minSampleWeight"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(percentile.MarginFraction, fldPath.Child("marginFraction"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateFraction(percentile.Percentile, fldPath.Child("percentile"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNumberGreaterThan(percentile.TargetUtilization, 0, fldPath.Child("targetUtilization"))...)
+	allErrs = append(allErrs, ValidateHistogramConfig(&percentile.Histogram, fldPath.Child("histogram"))...)
+	if percentile.Bounds != nil {
+		allErrs = append(allErrs, ValidateBounds(percentile.Bounds, fldPath.Child("bounds"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateLinear validates a Linear config.
+func ValidateLinear(linear *predictionapi.Linear, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, utilvalidation.ValidateInterval(linear.SampleInterval, fldPath.Child("sampleInterval"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(linear.HistoryLength, fldPath.Child("historyLength"))...)
+
+	return allErrs
+}
+
+// ValidateHoltWinters validates a HoltWinters config. The season must be a multiple of the sample
+// interval, and the history must cover at least two seasons to initialize the season.
+func ValidateHoltWinters(holtWinters *predictionapi.HoltWinters, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, utilvalidation.ValidateInterval(holtWinters.SampleInterval, fldPath.Child("sampleInterval"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(holtWinters.HistoryLength, fldPath.Child("historyLength"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(holtWinters.SeasonLength, fldPath.Child("seasonLength"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateFraction(holtWinters.Alpha, fldPath.Child("alpha"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateFraction(holtWinters.Beta, fldPath.Child("beta"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateFraction(holtWinters.Gamma, fldPath.Child("gamma"))...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	sampleInterval, _ := duration.Parse(holtWinters.SampleInterval)
+	historyLength, _ := duration.Parse(holtWinters.HistoryLength)
+	seasonLength, _ := duration.Parse(holtWinters.SeasonLength)
+	if seasonLength > 0 && sampleInterval > 0 && seasonLength%sampleInterval != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("seasonLength"), holtWinters.SeasonLength, "must be a multiple of sampleInterval"))
+	}
+	if seasonLength > 0 && historyLength > 0 && historyLength < 2*seasonLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("historyLength"), holtWinters.HistoryLength, "must be at least two seasonLength"))
+	}
+
+	return allErrs
+}
+
+// ValidateBounds validates a Bounds request.
+func ValidateBounds(bounds *predictionapi.Bounds, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	levelPath := fldPath.Child("confidenceLevel")
+	if bounds.ConfidenceLevel == "" {
+		allErrs = append(allErrs, field.Required(levelPath, ""))
+		return allErrs
+	}
+	allErrs = append(allErrs, utilvalidation.ValidateFraction(bounds.ConfidenceLevel, levelPath)...)
+	if len(allErrs) == 0 {
+		if level, _ := strconv.ParseFloat(bounds.ConfidenceLevel, 64); level == 0 || level == 1 {
+			allErrs = append(allErrs, field.Invalid(levelPath, bounds.ConfidenceLevel, "must be between 0 and 1 exclusive"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateHistogramConfig validates a HistogramConfig.
+func ValidateHistogramConfig(histogram *predictionapi.HistogramConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(histogram.MaxValue, fldPath.Child("maxValue"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(histogram.Epsilon, fldPath.Child("epsilon"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateDuration(histogram.HalfLife, fldPath.Child("halfLife"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(histogram.BucketSize, fldPath.Child("bucketSize"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNonNegativeNumber(histogram.FirstBucketSize, fldPath.Child("firstBucketSize"))...)
+	allErrs = append(allErrs, utilvalidation.ValidateNumberGreaterThan(histogram.BucketSizeGrowthRatio, 1, fldPath.Child("bucketSizeGrowthRatio"))...)
+
+	return allErrs
+}
+
+// ValidateClusterNodePrediction validates a ClusterNodePrediction.
+func ValidateClusterNodePrediction(cnp *predictionapi.ClusterNodePrediction) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	specPath := field.NewPath("spec")
+	if cnp.Spec.PredictionTemplate == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("template"), ""))
+		return allErrs
+	}
+
+	// The target ref of the template is set to each selected node, so only the metrics are validated.
+	templateSpecPath := specPath.Child("template", "spec")
+	for i := range cnp.Spec.PredictionTemplate.Spec.PredictionMetrics {
+		idxPath := templateSpecPath.Child("predictionMetrics").Index(i)
+		metric := &cnp.Spec.PredictionTemplate.Spec.PredictionMetrics[i]
+		allErrs = append(allErrs, ValidatePredictionMetric(metric, idxPath)...)
+		allErrs = append(allErrs, validatePlaceholders(metric, idxPath)...)
+	}
+
+	return allErrs
+}
+
+// validatePlaceholders validates the placeholders of the template metric, see clusternode.Render.
+func validatePlaceholders(metric *predictionapi.PredictionMetric, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if metric.ExpressionQuery != nil {
+		if err := clusternode.CheckPlaceholders(metric.ExpressionQuery.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("expressionQuery", "expression"), metric.ExpressionQuery.Expression, err.Error()))
+		}
+	}
+	if metric.MetricQuery != nil {
+		for i, condition := range metric.MetricQuery.QueryConditions {
+			for j, value := range condition.Value {
+				if err := clusternode.CheckPlaceholders(value); err != nil {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("metricQuery", "labels").Index(i).Child("value").Index(j), value, err.Error()))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidatePodGroupPrediction validates a PodGroupPrediction.
+func ValidatePodGroupPrediction(pgp *predictionapi.PodGroupPrediction) field.ErrorList {
+	return ValidatePodGroupPredictionSpec(&pgp.Spec, field.NewPath("spec"))
+}
+
+// ValidatePodGroupPredictionSpec validates a PodGroupPredictionSpec. The pod group
+// must be set by at least one of its pods, workload ref or label selector.
+func ValidatePodGroupPredictionSpec(spec *predictionapi.PodGroupPredictionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	emptySelector := len(spec.LabelSelector.MatchLabels) == 0 && len(spec.LabelSelector.MatchExpressions) == 0
+	if len(spec.Pods) == 0 && spec.WorkloadRef == nil && emptySelector {
+		allErrs = append(allErrs, field.Required(fldPath, "one of pods, workloadRef or labelSelector must be set"))
+	}
+	for i, pod := range spec.Pods {
+		if pod == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("pods").Index(i), ""))
+		}
+	}
+	if spec.WorkloadRef != nil {
+		if spec.WorkloadRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("workloadRef", "kind"), ""))
+		}
+		if spec.WorkloadRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("workloadRef", "name"), ""))
+		}
+	}
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&spec.LabelSelector, fldPath.Child("labelSelector"))...)
+
+	allErrs = append(allErrs, validateMode(spec.Mode, fldPath.Child("mode"))...)
+	if spec.PredictionWindow.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("predictionWindow"), spec.PredictionWindow.Duration.String(), "must be greater than or equal to 0"))
+	} else if spec.PredictionWindow.Duration > 0 && spec.Mode != predictionapi.PredictionModeRange {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("predictionWindow"), fmt.Sprintf("may only be set when mode is %s", predictionapi.PredictionModeRange)))
+	}
+	if spec.Start != nil && spec.End != nil && !spec.End.After(spec.Start.Time) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), spec.End.String(), "must be after start"))
+	}
+
+	allErrs = append(allErrs, ValidateMetricPredictionConfigs(spec.MetricPredictionConfigs, fldPath.Child("metricPredictionConfigs"))...)
+	return allErrs
+}
+
+// ValidateNodePrediction validates a NodePrediction.
+func ValidateNodePrediction(np *predictionapi.NodePrediction) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateMode(np.Spec.Mode, specPath.Child("mode"))...)
+	if np.Spec.Period.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("period"), np.Spec.Period.Duration.String(), "must be greater than or equal to 0"))
+	}
+	allErrs = append(allErrs, ValidateMetricPredictionConfigs(np.Spec.MetricPredictionConfigs, specPath.Child("metricPredictionConfigs"))...)
+
+	return allErrs
+}
+
+// ValidateMetricPredictionConfigs validates MetricPredictionConfigs, whose metric names must be unique.
+func ValidateMetricPredictionConfigs(configs []predictionapi.MetricPredictionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	metricNames := sets.NewString()
+	for i := range configs {
+		idxPath := fldPath.Index(i)
+		config := &configs[i]
+		if config.MetricName == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("metricName"), ""))
+		} else if metricNames.Has(config.MetricName) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("metricName"), config.MetricName))
+		}
+		metricNames.Insert(config.MetricName)
+
+		// The algorithm of a config is inlined in it.
+		algorithm := predictionapi.Algorithm{
+			AlgorithmType: config.AlgorithmType,
+			DSP:           config.DSP,
+			Percentile:    config.Percentile,
+			Linear:        config.Linear,
+			HoltWinters:   config.HoltWinters,
+		}
+		allErrs = append(allErrs, ValidateAlgorithm(&algorithm, idxPath)...)
+	}
+
+	return allErrs
+}
+
+func validateMode(mode predictionapi.PredictionMode, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if mode != "" && !supportedModes.Has(string(mode)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, mode, supportedModes.List()))
+	}
+	return allErrs
+}
@@ -0,0 +1,202 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	predictionapi "github.com/gocrane/api/prediction/v1alpha1"
+)
+
+func TestValidatePodGroupPrediction(t *testing.T) {
+	start := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(-time.Hour))
+
+	tests := []struct {
+		name     string
+		spec     predictionapi.PodGroupPredictionSpec
+		wantErrs []string
+	}{
+		{
+			name: "valid",
+			spec: predictionapi.PodGroupPredictionSpec{
+				LabelSelector:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Mode:             predictionapi.PredictionModeRange,
+				PredictionWindow: metav1.Duration{Duration: 24 * time.Hour},
+				MetricPredictionConfigs: []predictionapi.MetricPredictionConfig{
+					{MetricName: "cpu", AlgorithmType: predictionapi.AlgorithmTypeDSP},
+				},
+			},
+		},
+		{
+			name:     "no pod group",
+			spec:     predictionapi.PodGroupPredictionSpec{},
+			wantErrs: []string{"spec"},
+		},
+		{
+			name: "invalid fields",
+			spec: predictionapi.PodGroupPredictionSpec{
+				Pods:             []string{"web-0"},
+				Mode:             predictionapi.PredictionModeInstant,
+				PredictionWindow: metav1.Duration{Duration: time.Hour},
+				Start:            &start,
+				End:              &end,
+				MetricPredictionConfigs: []predictionapi.MetricPredictionConfig{
+					{MetricName: "cpu", AlgorithmType: predictionapi.AlgorithmTypeDSP},
+					{MetricName: "cpu", AlgorithmType: "arima"},
+				},
+			},
+			wantErrs: []string{
+				"spec.predictionWindow",
+				"spec.end",
+				"spec.metricPredictionConfigs[1].metricName",
+				"spec.metricPredictionConfigs[1].algorithmType",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidatePodGroupPrediction(&predictionapi.PodGroupPrediction{Spec: tt.spec})
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if len(got) != len(tt.wantErrs) {
+				t.Fatalf("got errors %v, want on fields %v", errs, tt.wantErrs)
+			}
+			for i := range got {
+				if got[i] != tt.wantErrs[i] {
+					t.Errorf("got error on %s, want on %s", got[i], tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateHoltWinters(t *testing.T) {
+	tests := []struct {
+		name        string
+		holtWinters predictionapi.HoltWinters
+		wantErrs    []string
+	}{
+		{
+			name:        "valid",
+			holtWinters: predictionapi.HoltWinters{SampleInterval: "1m", HistoryLength: "7d", SeasonLength: "1d", Alpha: "0.5", Beta: "0.1", Gamma: "0.3"},
+		},
+		{
+			name:        "invalid factors",
+			holtWinters: predictionapi.HoltWinters{Alpha: "1.5", Gamma: "-0.1"},
+			wantErrs:    []string{"holtWinters.alpha", "holtWinters.gamma"},
+		},
+		{
+			name:        "short history",
+			holtWinters: predictionapi.HoltWinters{SampleInterval: "7m", HistoryLength: "1d", SeasonLength: "1d"},
+			wantErrs:    []string{"holtWinters.seasonLength", "holtWinters.historyLength"},
+		},
+		{
+			name:        "zero sample interval",
+			holtWinters: predictionapi.HoltWinters{SampleInterval: "0s", HistoryLength: "7d", SeasonLength: "1d"},
+			wantErrs:    []string{"holtWinters.sampleInterval"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateHoltWinters(&tt.holtWinters, field.NewPath("holtWinters"))
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("got errors %v, want on fields %v", errs, tt.wantErrs)
+			}
+			for i, err := range errs {
+				if err.Field != tt.wantErrs[i] {
+					t.Errorf("got error on %s, want on %s", err.Field, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSampleInterval(t *testing.T) {
+	for _, interval := range []string{"0s", "500ms", "-1m"} {
+		fldPath := field.NewPath("algorithm")
+		errs := ValidateDSP(&predictionapi.DSP{SampleInterval: interval}, fldPath.Child("dsp"))
+		errs = append(errs, ValidatePercentile(&predictionapi.Percentile{SampleInterval: interval}, fldPath.Child("percentile"))...)
+		errs = append(errs, ValidateLinear(&predictionapi.Linear{SampleInterval: interval}, fldPath.Child("linear"))...)
+		want := []string{"algorithm.dsp.sampleInterval", "algorithm.percentile.sampleInterval", "algorithm.linear.sampleInterval"}
+		if len(errs) != len(want) {
+			t.Fatalf("got errors %v for interval %s, want on fields %v", errs, interval, want)
+		}
+		for i, err := range errs {
+			if err.Field != want[i] {
+				t.Errorf("got error on %s for interval %s, want on %s", err.Field, interval, want[i])
+			}
+		}
+	}
+}
+
+func TestValidatePercentile(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentile predictionapi.Percentile
+		wantErrs   []string
+	}{
+		{
+			name:       "valid",
+			percentile: predictionapi.Percentile{TargetUtilization: "0.5", Histogram: predictionapi.HistogramConfig{BucketSizeGrowthRatio: "1.05"}},
+		},
+		{
+			name:       "zero target utilization",
+			percentile: predictionapi.Percentile{TargetUtilization: "0"},
+			wantErrs:   []string{"percentile.targetUtilization"},
+		},
+		{
+			name:       "bucket size growth ratio not above 1",
+			percentile: predictionapi.Percentile{Histogram: predictionapi.HistogramConfig{BucketSizeGrowthRatio: "1"}},
+			wantErrs:   []string{"percentile.histogram.bucketSizeGrowthRatio"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidatePercentile(&tt.percentile, field.NewPath("percentile"))
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("got errors %v, want on fields %v", errs, tt.wantErrs)
+			}
+			for i, err := range errs {
+				if err.Field != tt.wantErrs[i] {
+					t.Errorf("got error on %s, want on %s", err.Field, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateClusterNodePredictionPlaceholders(t *testing.T) {
+	cnp := &predictionapi.ClusterNodePrediction{
+		Spec: predictionapi.ClusterNodePredictionSpec{
+			PredictionTemplate: &predictionapi.PredictionTemplate{
+				Spec: predictionapi.TimeSeriesPredictionSpec{
+					PredictionMetrics: []predictionapi.PredictionMetric{{
+						ResourceIdentifier: "cpu",
+						Type:               predictionapi.MetricQueryMetricType,
+						MetricQuery: &predictionapi.MetricQuery{
+							MetricName: "node_cpu_seconds_total",
+							QueryConditions: []predictionapi.QueryCondition{
+								{Key: "instance", Operator: predictionapi.OperatorEqual, Value: []string{"${node.address}"}},
+							},
+						},
+						Algorithm: predictionapi.Algorithm{AlgorithmType: predictionapi.AlgorithmTypeDSP},
+					}},
+				},
+			},
+		},
+	}
+
+	errs := ValidateClusterNodePrediction(cnp)
+	want := "spec.template.spec.predictionMetrics[0].metricQuery.labels[0].value[0]"
+	if len(errs) != 1 || errs[0].Field != want {
+		t.Errorf("got errors %v, want one on %s", errs, want)
+	}
+}
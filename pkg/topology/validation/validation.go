@@ -0,0 +1,71 @@
+// Package validation validates the objects of the topology API group.
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	topologyapi "github.com/gocrane/api/topology/v1alpha1"
+)
+
+var (
+	supportedCPUManagerPolicies = sets.NewString(
+		string(topologyapi.CPUManagerPolicyNone),
+		string(topologyapi.CPUManagerPolicyStatic),
+	)
+	supportedTopologyManagerPolicies = sets.NewString(
+		string(topologyapi.TopologyManagerPolicyNone),
+		string(topologyapi.TopologyManagerPolicySingleNUMANodePodLevel),
+	)
+	supportedZoneTypes = sets.NewString(
+		string(topologyapi.ZoneTypeNode),
+		string(topologyapi.ZoneTypeSocket),
+		string(topologyapi.ZoneTypeCore),
+	)
+)
+
+// ValidateNodeResourceTopology validates a NodeResourceTopology.
+// Zone names must be unique, and parents and costs must refer to zones of the same object.
+func ValidateNodeResourceTopology(nrt *topologyapi.NodeResourceTopology) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	policyPath := field.NewPath("craneManagerPolicy")
+	if !supportedCPUManagerPolicies.Has(string(nrt.CraneManagerPolicy.CPUManagerPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("cpuManagerPolicy"), nrt.CraneManagerPolicy.CPUManagerPolicy, supportedCPUManagerPolicies.List()))
+	}
+	if nrt.CraneManagerPolicy.TopologyManagerPolicy != "" && !supportedTopologyManagerPolicies.Has(string(nrt.CraneManagerPolicy.TopologyManagerPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("topologyManagerPolicy"), nrt.CraneManagerPolicy.TopologyManagerPolicy, supportedTopologyManagerPolicies.List()))
+	}
+
+	zonesPath := field.NewPath("zones")
+	names := sets.NewString()
+	for i, zone := range nrt.Zones {
+		idxPath := zonesPath.Index(i)
+		if zone.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else if names.Has(zone.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), zone.Name))
+		}
+		names.Insert(zone.Name)
+		if !supportedZoneTypes.Has(string(zone.Type)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("type"), zone.Type, supportedZoneTypes.List()))
+		}
+		if zone.Resources != nil && zone.Resources.ReservedCPUNums < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("resources", "reservedCPUNums"), zone.Resources.ReservedCPUNums, "must be greater than or equal to 0"))
+		}
+	}
+
+	for i, zone := range nrt.Zones {
+		idxPath := zonesPath.Index(i)
+		if zone.Parent != "" && !names.Has(zone.Parent) {
+			allErrs = append(allErrs, field.NotFound(idxPath.Child("parent"), zone.Parent))
+		}
+		for j, cost := range zone.Costs {
+			if !names.Has(cost.Name) {
+				allErrs = append(allErrs, field.NotFound(idxPath.Child("costs").Index(j).Child("name"), cost.Name))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,53 @@
+// Package duration parses the duration strings used across the crane APIs,
+// such as DSP.HistoryLength or RecommendationRuleSpec.RunInterval.
+package duration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Day is the duration of the "d" unit.
+	Day = 24 * time.Hour
+	// Week is the duration of the "w" unit.
+	Week = 7 * Day
+)
+
+// Parse parses a duration string. In addition to the units of time.ParseDuration,
+// it accepts the "d" (24h) and "w" (7d) units as a leading component, e.g. "7d", "1w12h" or "1d2h30m".
+func Parse(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d time.Duration
+	rest := s
+	for _, unit := range []struct {
+		suffix string
+		value  time.Duration
+	}{{"w", Week}, {"d", Day}} {
+		i := strings.Index(rest, unit.suffix)
+		if i < 0 {
+			continue
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += time.Duration(n) * unit.value
+		rest = rest[i+1:]
+	}
+
+	if rest != "" {
+		remainder, err := time.ParseDuration(rest)
+		if err != nil || remainder < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += remainder
+	}
+	return d, nil
+}
@@ -0,0 +1,83 @@
+// Package validation holds field validators shared by the API group validations.
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane/api/pkg/util/duration"
+)
+
+// ValidateNumber checks that value, when set, is a finite number.
+func ValidateNumber(value string, fldPath *field.Path) field.ErrorList {
+	_, allErrs := parseNumber(value, fldPath)
+	return allErrs
+}
+
+// ValidateNonNegativeNumber checks that value, when set, is a finite number not less than 0.
+func ValidateNonNegativeNumber(value string, fldPath *field.Path) field.ErrorList {
+	f, allErrs := parseNumber(value, fldPath)
+	if len(allErrs) == 0 && f < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// ValidateNumberGreaterThan checks that value, when set, is a finite number greater than min.
+func ValidateNumberGreaterThan(value string, min float64, fldPath *field.Path) field.ErrorList {
+	f, allErrs := parseNumber(value, fldPath)
+	if len(allErrs) == 0 && value != "" && f <= min {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("must be greater than %v", min)))
+	}
+	return allErrs
+}
+
+// ValidateFraction checks that value, when set, is a number between 0 and 1 inclusive.
+func ValidateFraction(value string, fldPath *field.Path) field.ErrorList {
+	f, allErrs := parseNumber(value, fldPath)
+	if len(allErrs) == 0 && (f < 0 || f > 1) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be between 0 and 1 inclusive"))
+	}
+	return allErrs
+}
+
+// ValidateDuration checks that value, when set, is a duration accepted by duration.Parse.
+func ValidateDuration(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value == "" {
+		return allErrs
+	}
+	if _, err := duration.Parse(value); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be a duration such as 30s, 1h or 7d"))
+	}
+	return allErrs
+}
+
+// ValidateInterval checks that value, when set, is a duration accepted by duration.Parse of at least one second,
+// such as the sample interval of an algorithm.
+func ValidateInterval(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if value == "" {
+		return allErrs
+	}
+	if d, err := duration.Parse(value); err != nil || d < time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be a duration of at least 1s such as 30s, 1m or 1h"))
+	}
+	return allErrs
+}
+
+func parseNumber(value string, fldPath *field.Path) (float64, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	if value == "" {
+		return 0, allErrs
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be a number"))
+	}
+	return f, allErrs
+}
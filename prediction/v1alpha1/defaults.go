@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_Linear sets the defaults of a Linear.
+func SetDefaults_Linear(obj *Linear) {
+	if obj.SampleInterval == "" {
+		obj.SampleInterval = "1m"
+	}
+	if obj.HistoryLength == "" {
+		obj.HistoryLength = "1d"
+	}
+}
+
+// SetDefaults_HoltWinters sets the defaults of a HoltWinters.
+func SetDefaults_HoltWinters(obj *HoltWinters) {
+	if obj.SampleInterval == "" {
+		obj.SampleInterval = "1m"
+	}
+	if obj.HistoryLength == "" {
+		obj.HistoryLength = "7d"
+	}
+	if obj.SeasonLength == "" {
+		obj.SeasonLength = "1d"
+	}
+	if obj.Alpha == "" {
+		obj.Alpha = "0.5"
+	}
+	if obj.Beta == "" {
+		obj.Beta = "0.1"
+	}
+	if obj.Gamma == "" {
+		obj.Gamma = "0.3"
+	}
+}
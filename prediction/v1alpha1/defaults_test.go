@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSchemeDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add to scheme: %v", err)
+	}
+
+	tsp := &TimeSeriesPrediction{
+		Spec: TimeSeriesPredictionSpec{
+			PredictionMetrics: []PredictionMetric{
+				{Algorithm: Algorithm{AlgorithmType: AlgorithmTypeLinear, Linear: &Linear{HistoryLength: "3d"}}},
+				{Algorithm: Algorithm{AlgorithmType: AlgorithmTypeHoltWinters, HoltWinters: &HoltWinters{Alpha: "0.8"}}},
+			},
+		},
+	}
+	scheme.Default(tsp)
+
+	linear := tsp.Spec.PredictionMetrics[0].Algorithm.Linear
+	if linear.SampleInterval != "1m" || linear.HistoryLength != "3d" {
+		t.Errorf("linear defaults = %+v, want sampleInterval 1m and historyLength 3d", linear)
+	}
+	want := HoltWinters{SampleInterval: "1m", HistoryLength: "7d", SeasonLength: "1d", Alpha: "0.8", Beta: "0.1", Gamma: "0.3"}
+	if got := *tsp.Spec.PredictionMetrics[1].Algorithm.HoltWinters; got != want {
+		t.Errorf("holtWinters defaults = %+v, want %+v", got, want)
+	}
+}
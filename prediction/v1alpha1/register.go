@@ -34,7 +34,7 @@ func init() {
 	// We only register manually written functions here. The registration of the
 	// generated functions takes place in the generated files. The separation
 	// makes the code compile even when the generated files are missing.
-	localSchemeBuilder.Register(addKnownTypes)
+	localSchemeBuilder.Register(addKnownTypes, addDefaultingFuncs)
 }
 
 // Adds the list of known types to Scheme.
@@ -44,6 +44,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TimeSeriesPredictionList{},
 		&ClusterNodePrediction{},
 		&ClusterNodePredictionList{},
+		&PodGroupPrediction{},
+		&PodGroupPredictionList{},
+		&NodePrediction{},
+		&NodePredictionList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -8,13 +9,16 @@ import (
 type AlgorithmType string
 
 const (
-	AlgorithmTypePercentile AlgorithmType = "percentile"
-	AlgorithmTypeDSP        AlgorithmType = "dsp"
+	AlgorithmTypePercentile  AlgorithmType = "percentile"
+	AlgorithmTypeDSP         AlgorithmType = "dsp"
+	AlgorithmTypeLinear      AlgorithmType = "linear"
+	AlgorithmTypeHoltWinters AlgorithmType = "holtWinters"
 )
 
 // PredictionMode represents the prediction time series mode.
 type PredictionMode string
 
+// The prediction modes are untyped so that they still assign to and compare with plain strings.
 const (
 	// PredictionModeInstant means predicting a single point in the future, for example the maximum value for the next hour
 	PredictionModeInstant = "instant"
@@ -48,15 +52,56 @@ type ClusterNodePrediction struct {
 	Status ClusterNodePredictionStatus `json:"status,omitempty"`
 }
 
+// ClusterNodePredictionSpec is a description of a ClusterNodePrediction.
+//
+// A TimeSeriesPrediction is rendered from the template for each node selected by NodeSelector, in
+// the namespace of the ClusterNodePrediction. It is named after the ClusterNodePrediction and the
+// node, targets the node and is controlled by the ClusterNodePrediction. The placeholders
+// ${node.name} and ${node.labels[<key>]} in the expressions of ExpressionQuery and in the values
+// of the QueryConditions of MetricQuery are replaced with the name and the labels of the node.
 type ClusterNodePredictionSpec struct {
 	NodeSelector       map[string]string   `json:"nodeSelector,omitempty"`
 	PredictionTemplate *PredictionTemplate `json:"template,omitempty"`
 }
 
 type ClusterNodePredictionStatus struct {
-	DesiredNumberCreated int                `json:"desiredNumberCreated,omitempty"`
-	CurrentNumberCreated int                `json:"currentNumberCreated,omitempty"`
-	Conditions           []metav1.Condition `json:"conditions,omitempty"`
+	DesiredNumberCreated int `json:"desiredNumberCreated,omitempty"`
+	CurrentNumberCreated int `json:"currentNumberCreated,omitempty"`
+	// ReadyNumber is the number of TimeSeriesPredictions of selected nodes that are ready.
+	// +optional
+	ReadyNumber int `json:"readyNumber,omitempty"`
+	// Nodes is the state of the TimeSeriesPrediction of each selected node, sorted by node name.
+	// +optional
+	Nodes      []ClusterNodePredictionNodeStatus `json:"nodes,omitempty"`
+	Conditions []metav1.Condition                `json:"conditions,omitempty"`
+}
+
+// ClusterNodePredictionNodePhase is the phase of the TimeSeriesPrediction of a node.
+type ClusterNodePredictionNodePhase string
+
+const (
+	// ClusterNodePredictionNodePending means the TimeSeriesPrediction of the node is not created yet.
+	ClusterNodePredictionNodePending ClusterNodePredictionNodePhase = "Pending"
+	// ClusterNodePredictionNodeCreated means the TimeSeriesPrediction of the node is created but not ready.
+	ClusterNodePredictionNodeCreated ClusterNodePredictionNodePhase = "Created"
+	// ClusterNodePredictionNodeReady means the TimeSeriesPrediction of the node is ready.
+	ClusterNodePredictionNodeReady ClusterNodePredictionNodePhase = "Ready"
+	// ClusterNodePredictionNodeFailed means the template cannot be rendered for the node.
+	ClusterNodePredictionNodeFailed ClusterNodePredictionNodePhase = "Failed"
+)
+
+// ClusterNodePredictionNodeStatus is the state of the TimeSeriesPrediction of a node.
+type ClusterNodePredictionNodeStatus struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+	// PredictionName is the name of the TimeSeriesPrediction of the node.
+	PredictionName string `json:"predictionName,omitempty"`
+	// Phase is the phase of the TimeSeriesPrediction of the node.
+	Phase ClusterNodePredictionNodePhase `json:"phase"`
+	// Message is a human readable message indicating details about the phase, such as why the
+	// template cannot be rendered.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type PredictionTemplate struct {
@@ -71,6 +116,17 @@ type DSP struct {
 	HistoryLength string `json:"historyLength,omitempty"`
 	// Estimator
 	Estimators Estimators `json:"estimators,omitempty"`
+	// Bounds requests confidence bounds around the predicted time series.
+	// +optional
+	Bounds *Bounds `json:"bounds,omitempty"`
+}
+
+// Bounds requests an upper and a lower bound around the predicted time series.
+type Bounds struct {
+	// ConfidenceLevel is the probability that the actual value falls between the lower and the
+	// upper bound, between 0 and 1 exclusive, such as 0.9. With a level of 0.8 the bounds are the
+	// p10 and p90 quantiles of the prediction.
+	ConfidenceLevel string `json:"confidenceLevel"`
 }
 
 type Estimators struct {
@@ -101,6 +157,52 @@ type Percentile struct {
 	MarginFraction    string          `json:"marginFraction,omitempty"`
 	Percentile        string          `json:"percentile,omitempty"`
 	TargetUtilization string          `json:"targetUtilization,omitempty"`
+	// Bounds requests confidence bounds around the predicted value.
+	// +optional
+	Bounds *Bounds `json:"bounds,omitempty"`
+}
+
+// Linear fits a least squares line to the history of a time series and extends it over the
+// prediction window, for time series with a trend.
+type Linear struct {
+	// SampleInterval is the sampling interval of metrics.
+	// +optional
+	// +kubebuilder:default="1m"
+	SampleInterval string `json:"sampleInterval,omitempty"`
+	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	// +optional
+	// +kubebuilder:default="1d"
+	HistoryLength string `json:"historyLength,omitempty"`
+}
+
+// HoltWinters is triple exponential smoothing with an additive trend and season, for seasonal
+// time series with a trend.
+type HoltWinters struct {
+	// SampleInterval is the sampling interval of metrics.
+	// +optional
+	// +kubebuilder:default="1m"
+	SampleInterval string `json:"sampleInterval,omitempty"`
+	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	// It must cover at least two seasons.
+	// +optional
+	// +kubebuilder:default="7d"
+	HistoryLength string `json:"historyLength,omitempty"`
+	// SeasonLength is the length of a season, a multiple of SampleInterval.
+	// +optional
+	// +kubebuilder:default="1d"
+	SeasonLength string `json:"seasonLength,omitempty"`
+	// Alpha is the smoothing factor of the level, between 0 and 1.
+	// +optional
+	// +kubebuilder:default="0.5"
+	Alpha string `json:"alpha,omitempty"`
+	// Beta is the smoothing factor of the trend, between 0 and 1.
+	// +optional
+	// +kubebuilder:default="0.1"
+	Beta string `json:"beta,omitempty"`
+	// Gamma is the smoothing factor of the season, between 0 and 1.
+	// +optional
+	// +kubebuilder:default="0.3"
+	Gamma string `json:"gamma,omitempty"`
 }
 
 type HistogramConfig struct {
@@ -144,6 +246,32 @@ type TimeSeriesPredictionSpec struct {
 	TargetRef v1.ObjectReference `json:"targetRef,omitempty"`
 	// PredictionWindowSeconds is a time window in seconds, indicating how long to predict in the future.
 	PredictionWindowSeconds int32 `json:"predictionWindowSeconds,omitempty"`
+	// SampleEncoding is the encoding of the predicted samples in the status, Samples by default.
+	// Compact keeps the status of long predictions with many time series under the size limit of
+	// an object.
+	// +optional
+	// +kubebuilder:validation:Enum=Samples;Compact
+	SampleEncoding SampleEncoding `json:"sampleEncoding,omitempty"`
+	// AccuracyPolicy configures how the accuracy of the predictions is tracked, accuracy is not
+	// tracked when it is nil.
+	// +optional
+	AccuracyPolicy *AccuracyPolicy `json:"accuracyPolicy,omitempty"`
+}
+
+// AccuracyPolicy configures the comparison of past predicted samples with actual samples.
+type AccuracyPolicy struct {
+	// WindowSeconds is the rolling window in seconds over which the accuracy is computed.
+	// Default is 86400, one day.
+	// +optional
+	WindowSeconds int32 `json:"windowSeconds,omitempty"`
+	// MAPEThreshold is the mean absolute percentage error, as a fraction such as 0.2, above which
+	// the accuracy of a metric is degraded.
+	// +optional
+	MAPEThreshold string `json:"mapeThreshold,omitempty"`
+	// RMSEThreshold is the root mean square error, in the unit of the metric, above which the
+	// accuracy of a metric is degraded.
+	// +optional
+	RMSEThreshold string `json:"rmseThreshold,omitempty"`
 }
 
 // TimeSeriesPredictionStatus is the status of a TimeSeriesPrediction.
@@ -161,6 +289,9 @@ type PredictionConditionType string
 const (
 	// TimeSeriesPredictionConditionReady means the prediction data is available to consume
 	TimeSeriesPredictionConditionReady PredictionConditionType = "Ready"
+	// TimeSeriesPredictionConditionAccuracyDegraded means the error of the prediction of at least one
+	// metric exceeds a threshold of the AccuracyPolicy, consumers should not rely on it
+	TimeSeriesPredictionConditionAccuracyDegraded PredictionConditionType = "AccuracyDegraded"
 )
 
 // PredictionMetric describe what metric of your time series prediction, how to query, use which algorithm to predict.
@@ -182,6 +313,12 @@ type PredictionMetric struct {
 	ExpressionQuery *ExpressionQuery `json:"expressionQuery,omitempty"`
 	// Algorithm is the algorithm used by this prediction metric.
 	Algorithm Algorithm `json:"algorithm,omitempty"`
+	// Mode is the prediction mode of this metric. In range mode the status holds the predicted
+	// time series over the prediction window, in instant mode it holds the maximum of each
+	// predicted time series over the prediction window. The default is range.
+	// +optional
+	// +kubebuilder:validation:Enum=instant;range
+	Mode PredictionMode `json:"mode,omitempty"`
 }
 
 // MetricType is the type of metric
@@ -237,7 +374,7 @@ const (
 
 // Algorithm describe the algorithm params
 type Algorithm struct {
-	// AlgorithmType is the algorithm type, currently supports dsp and percentile.
+	// AlgorithmType is the algorithm type, currently supports dsp, percentile, linear and holtWinters.
 	AlgorithmType AlgorithmType `json:"algorithmType,omitempty"`
 	// +optional
 	// DSP is an algorithm which use FFT to deal with time series, typically it is used to predict some periodic time series
@@ -245,6 +382,12 @@ type Algorithm struct {
 	// +optional
 	// Percentile is an algorithm which use exponential time decay histogram, it can predict a reasonable value according your history time series
 	Percentile *Percentile `json:"percentile,omitempty"`
+	// +optional
+	// Linear is an algorithm which use linear regression, typically it is used to predict a time series with a trend
+	Linear *Linear `json:"linear,omitempty"`
+	// +optional
+	// HoltWinters is an algorithm which use triple exponential smoothing, typically it is used to predict a seasonal time series with a trend
+	HoltWinters *HoltWinters `json:"holtWinters,omitempty"`
 }
 
 type MetricTimeSeriesList []*MetricTimeSeries
@@ -254,12 +397,71 @@ type PredictionMetricStatus struct {
 	// ResourceIdentifier is a resource to identify the metric, but now it is just an identifier now.
 	// such as cpu, memory
 	ResourceIdentifier string `json:"resourceIdentifier,omitempty"`
-	// Prediction is the predicted time series data of the metric
+	// Mode is the prediction mode the status was predicted in.
+	// +optional
+	Mode PredictionMode `json:"mode,omitempty"`
+	// Prediction is the predicted time series data of the metric, set in range mode.
+	// +optional
 	Prediction []*MetricTimeSeries `json:"prediction,omitempty"`
+	// Instant is the predicted point of each time series of the metric, set in instant mode.
+	// +optional
+	Instant []InstantPrediction `json:"instant,omitempty"`
+	// ConfidenceLevel is the confidence level of the bounds, set when the algorithm was asked
+	// for Bounds.
+	// +optional
+	ConfidenceLevel string `json:"confidenceLevel,omitempty"`
+	// UpperBound is the upper bound of each time series of Prediction, with the same labels,
+	// set in range mode.
+	// +optional
+	UpperBound []*MetricTimeSeries `json:"upperBound,omitempty"`
+	// LowerBound is the lower bound of each time series of Prediction, with the same labels,
+	// set in range mode.
+	// +optional
+	LowerBound []*MetricTimeSeries `json:"lowerBound,omitempty"`
+	// Accuracy is the accuracy of the past predictions of the metric, set with an AccuracyPolicy.
+	// +optional
+	Accuracy *PredictionAccuracy `json:"accuracy,omitempty"`
 	// Specifies whether the prediction is Ready.
 	Ready bool `json:"ready"`
 }
 
+// InstantPrediction is a predicted time series reduced to a single point: its maximum over a window.
+type InstantPrediction struct {
+	// A collection of Labels of the predicted time series.
+	Labels []Label `json:"labels,omitempty"`
+	// Value is the maximum predicted value during the window.
+	Value string `json:"value,omitempty"`
+	// UpperBound is the maximum of the upper bound during the window, set with ConfidenceLevel.
+	// +optional
+	UpperBound string `json:"upperBound,omitempty"`
+	// LowerBound is the maximum of the lower bound during the window, set with ConfidenceLevel.
+	// +optional
+	LowerBound string `json:"lowerBound,omitempty"`
+	// WindowStart is the inclusive start of the window, in seconds since the epoch.
+	WindowStart int64 `json:"windowStart,omitempty"`
+	// WindowEnd is the exclusive end of the window, in seconds since the epoch.
+	WindowEnd int64 `json:"windowEnd,omitempty"`
+}
+
+// PredictionAccuracy compares the past predicted samples of a metric with its actual samples.
+type PredictionAccuracy struct {
+	// MAPE is the mean absolute percentage error as a fraction, samples whose actual value is 0
+	// are left out of it.
+	// +optional
+	MAPE string `json:"mape,omitempty"`
+	// RMSE is the root mean square error, in the unit of the metric.
+	// +optional
+	RMSE string `json:"rmse,omitempty"`
+	// Samples is the number of predicted samples compared with an actual sample.
+	Samples int32 `json:"samples"`
+	// WindowStart is the inclusive start of the window, in seconds since the epoch.
+	WindowStart int64 `json:"windowStart,omitempty"`
+	// WindowEnd is the exclusive end of the window, in seconds since the epoch.
+	WindowEnd int64 `json:"windowEnd,omitempty"`
+	// Degraded is whether an error exceeds a threshold of the AccuracyPolicy.
+	Degraded bool `json:"degraded"`
+}
+
 // MetricTimeSeries is a stream of samples that belong to a metric with a set of labels
 type MetricTimeSeries struct {
 	// A collection of Labels that are attached by monitoring system as metadata
@@ -267,8 +469,33 @@ type MetricTimeSeries struct {
 	Labels []Label `json:"labels,omitempty"`
 	// A collection of Samples in chronological order.
 	Samples []Sample `json:"samples,omitempty"`
+	// Compact is the samples in the compact encoding, set instead of Samples.
+	// +optional
+	Compact *CompactSamples `json:"compact,omitempty"`
+}
+
+// CompactSamples is a compact encoding of samples taken at a regular step.
+type CompactSamples struct {
+	// Start is the timestamp of the first sample, in seconds since the epoch.
+	Start int64 `json:"start"`
+	// Step is the duration in seconds between consecutive samples.
+	Step int64 `json:"step,omitempty"`
+	// Values are the values of the samples separated by commas, the i-th is the value of the sample
+	// at Start + i * Step. An empty value is a missing sample.
+	Values string `json:"values"`
 }
 
+// SampleEncoding is the encoding of the samples of a MetricTimeSeries.
+type SampleEncoding string
+
+const (
+	// SampleEncodingSamples encodes each sample as a Sample.
+	SampleEncodingSamples SampleEncoding = "Samples"
+	// SampleEncodingCompact encodes the samples as CompactSamples, or as Samples for a time series
+	// whose compact encoding would not be smaller.
+	SampleEncodingCompact SampleEncoding = "Compact"
+)
+
 // Sample pairs a Value with a Timestamp.
 type Sample struct {
 	Value     string `json:"value,omitempty"`
@@ -292,3 +519,176 @@ type TimeSeriesPredictionList struct {
 
 	Items []TimeSeriesPrediction `json:"items"`
 }
+
+// MetricPredictionConfig is the prediction config of a metric.
+type MetricPredictionConfig struct {
+	MetricName    string        `json:"metricName,omitempty"`
+	AlgorithmType AlgorithmType `json:"algorithmType,omitempty"`
+	// +optional
+	DSP *DSP `json:"dsp,omitempty"`
+	// +optional
+	Percentile *Percentile `json:"percentile,omitempty"`
+	// +optional
+	Linear *Linear `json:"linear,omitempty"`
+	// +optional
+	HoltWinters *HoltWinters `json:"holtWinters,omitempty"`
+}
+
+// Prediction define metrics prediction
+type Prediction map[v1.ResourceName]TimeSeries
+
+// TimeSeries
+type TimeSeries []Vector
+
+// Vector
+type Vector struct {
+	// CRD not support float64
+	Value     string `json:"value,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:webhooks:path=/mutate-podgroupprediction,mutating=true,failurePolicy=fail,groups=prediction.crane.io,resources=podgrouppredictions,verbs=create;update,versions=v1alpha1,name=prediction.crane.io_podgrouppredictions_webhook,sideEffects=none,admissionReviewVersions=v1
+// +kubebuilder:webhooks:verbs=create;update,path=/validate-podgroupprediction,mutating=false,failurePolicy=fail,groups=prediction.crane.io,resources=podgrouppredictions,versions=v1,name=prediction.crane.io_podgrouppredictions_webhook,sideEffects=none,admissionReviewVersions=v1
+
+// PodGroupPrediction is a prediction on the resource consumed by a pod group.
+// In kubernetes context, a pod group often refers to a batch of pods that satisfy a label selector.
+type PodGroupPrediction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodGroupPredictionSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status PodGroupPredictionStatus `json:"status,omitempty"`
+}
+
+// PodGroupPredictionSpec is a description of a PodGroupPrediction.
+type PodGroupPredictionSpec struct {
+	// Pods is a list of pod names that belong to this pod group. If not specified then WorkloadRef is invalid.
+	// The aggregator aggregate priority is  Pods > WorkloadRef > LabelSelector
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+	// WorkloadRef is a ref of workload(deployment/statefulsets).
+	// +optional
+	WorkloadRef *autoscalingv2.CrossVersionObjectReference `json:"workloadRef,omitempty"`
+	// LabelSelector is the aggregator label selector. aggregator group all data by same key .
+	// for example, [online: label=v1] denotes all pods with label label=v1 will aggregate by sum all the resources.
+	// +optional
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// Mode is the prediction time series mode. instant or range
+	Mode PredictionMode `json:"mode,omitempty"`
+	// PredictionWindow, for example, 24-hours means predicting time series in next 24 hours.
+	// This should be used only for PredictionModeRange.
+	// +optional
+	PredictionWindow metav1.Duration `json:"predictionWindow,omitempty"`
+	// Prediction start time. If not specified, the prediction starts from the object creationTimestamp.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// Prediction end time. If current time is after end, the prediction will be stopped and the status will not be updated afterward.
+	// If end is null, the prediction will never stop.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +optional
+	MetricPredictionConfigs []MetricPredictionConfig `json:"metricPredictionConfigs,omitempty"`
+}
+
+// PodGroupPredictionStatus
+type PodGroupPredictionStatus struct {
+	// Status
+	Status string `json:"status,omitempty"`
+	// Conditions is the condition of PodGroupPrediction
+	// +optional
+	Conditions []PodGroupPredictionCondition `json:"conditions,omitempty"`
+	// Containers is all the containers in pod group. excludes pause container.
+	// key is the namesapce/podname/containername
+	// +optional
+	Containers map[string]Prediction `json:"containers,omitempty"`
+	// Aggregation is the aggregated prediction value of all pods.
+	// +optional
+	Aggregation Prediction `json:"aggregation,omitempty"`
+}
+
+// PodGroupPredictionConditionType is a valid value for PodGroupPredictionCondition.Type
+type PodGroupPredictionConditionType string
+
+// PodGroupPredictionCondition contains details for the current condition of this pod.
+type PodGroupPredictionCondition struct {
+	// Type is the type of the condition.
+	Type PodGroupPredictionConditionType `json:"type,omitempty"`
+	// Status is the status of the condition.
+	// Can be True, False, Unknown.
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+	// Last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroupPredictionList is a list of PodGroupPrediction resources
+type PodGroupPredictionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PodGroupPrediction `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// NodePrediction is the node prediction resource, which is associated with a node.
+type NodePrediction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodePredictionResourceSpec `json:"spec"`
+
+	// +optional
+	Status NodePredictionResourceStatus `json:"status,omitempty"`
+}
+
+// NodePredictionResourceSpec is the specification of a node prediction.
+type NodePredictionResourceSpec struct {
+	// Mode is the prediction time series mode
+	Mode PredictionMode `json:"mode,omitempty"`
+	// Period is the prediction time series interval or step.
+	// +optional
+	Period metav1.Duration `json:"period,omitempty"`
+	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +optional
+	MetricPredictionConfigs []MetricPredictionConfig `json:"metricPredictionConfigs,omitempty"`
+}
+
+// NodePredictionResourceStatus represents information about the status of NodePrediction
+type NodePredictionResourceStatus struct {
+	// NextPossible is the predicted resource usage in next resolution point based on previous series.
+	// +optional
+	NextPossible Prediction `json:"nextPossible,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePredictionList is a list of NodePrediction resources
+type NodePredictionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodePrediction `json:"items"`
+}
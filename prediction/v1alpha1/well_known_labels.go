@@ -0,0 +1,11 @@
+package v1alpha1
+
+const (
+	// LabelClusterNodePredictionName is the label key of the TimeSeriesPredictions rendered for a
+	// ClusterNodePrediction. Its value is the name of the ClusterNodePrediction.
+	LabelClusterNodePredictionName = "prediction.crane.io/cluster-node-prediction-name"
+
+	// LabelNodeName is the label key of the TimeSeriesPredictions rendered for a node by a
+	// ClusterNodePrediction. Its value is the name of the node.
+	LabelNodeName = "prediction.crane.io/node-name"
+)
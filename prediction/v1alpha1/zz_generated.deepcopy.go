@@ -6,11 +6,28 @@
 package v1alpha1
 
 import (
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccuracyPolicy) DeepCopyInto(out *AccuracyPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccuracyPolicy.
+func (in *AccuracyPolicy) DeepCopy() *AccuracyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AccuracyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Algorithm) DeepCopyInto(out *Algorithm) {
 	*out = *in
@@ -22,6 +39,16 @@ func (in *Algorithm) DeepCopyInto(out *Algorithm) {
 	if in.Percentile != nil {
 		in, out := &in.Percentile, &out.Percentile
 		*out = new(Percentile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Linear != nil {
+		in, out := &in.Linear, &out.Linear
+		*out = new(Linear)
+		**out = **in
+	}
+	if in.HoltWinters != nil {
+		in, out := &in.HoltWinters, &out.HoltWinters
+		*out = new(HoltWinters)
 		**out = **in
 	}
 	return
@@ -37,6 +64,22 @@ func (in *Algorithm) DeepCopy() *Algorithm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bounds) DeepCopyInto(out *Bounds) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bounds.
+func (in *Bounds) DeepCopy() *Bounds {
+	if in == nil {
+		return nil
+	}
+	out := new(Bounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNodePrediction) DeepCopyInto(out *ClusterNodePrediction) {
 	*out = *in
@@ -98,6 +141,22 @@ func (in *ClusterNodePredictionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNodePredictionNodeStatus) DeepCopyInto(out *ClusterNodePredictionNodeStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNodePredictionNodeStatus.
+func (in *ClusterNodePredictionNodeStatus) DeepCopy() *ClusterNodePredictionNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNodePredictionNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNodePredictionSpec) DeepCopyInto(out *ClusterNodePredictionSpec) {
 	*out = *in
@@ -129,6 +188,11 @@ func (in *ClusterNodePredictionSpec) DeepCopy() *ClusterNodePredictionSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNodePredictionStatus) DeepCopyInto(out *ClusterNodePredictionStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ClusterNodePredictionNodeStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -149,10 +213,31 @@ func (in *ClusterNodePredictionStatus) DeepCopy() *ClusterNodePredictionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompactSamples) DeepCopyInto(out *CompactSamples) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompactSamples.
+func (in *CompactSamples) DeepCopy() *CompactSamples {
+	if in == nil {
+		return nil
+	}
+	out := new(CompactSamples)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DSP) DeepCopyInto(out *DSP) {
 	*out = *in
 	in.Estimators.DeepCopyInto(&out.Estimators)
+	if in.Bounds != nil {
+		in, out := &in.Bounds, &out.Bounds
+		*out = new(Bounds)
+		**out = **in
+	}
 	return
 }
 
@@ -262,6 +347,43 @@ func (in *HistogramConfig) DeepCopy() *HistogramConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HoltWinters) DeepCopyInto(out *HoltWinters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HoltWinters.
+func (in *HoltWinters) DeepCopy() *HoltWinters {
+	if in == nil {
+		return nil
+	}
+	out := new(HoltWinters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstantPrediction) DeepCopyInto(out *InstantPrediction) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]Label, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstantPrediction.
+func (in *InstantPrediction) DeepCopy() *InstantPrediction {
+	if in == nil {
+		return nil
+	}
+	out := new(InstantPrediction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Label) DeepCopyInto(out *Label) {
 	*out = *in
@@ -278,6 +400,22 @@ func (in *Label) DeepCopy() *Label {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Linear) DeepCopyInto(out *Linear) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Linear.
+func (in *Linear) DeepCopy() *Linear {
+	if in == nil {
+		return nil
+	}
+	out := new(Linear)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaxValueEstimator) DeepCopyInto(out *MaxValueEstimator) {
 	*out = *in
@@ -294,6 +432,42 @@ func (in *MaxValueEstimator) DeepCopy() *MaxValueEstimator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricPredictionConfig) DeepCopyInto(out *MetricPredictionConfig) {
+	*out = *in
+	if in.DSP != nil {
+		in, out := &in.DSP, &out.DSP
+		*out = new(DSP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentile != nil {
+		in, out := &in.Percentile, &out.Percentile
+		*out = new(Percentile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Linear != nil {
+		in, out := &in.Linear, &out.Linear
+		*out = new(Linear)
+		**out = **in
+	}
+	if in.HoltWinters != nil {
+		in, out := &in.HoltWinters, &out.HoltWinters
+		*out = new(HoltWinters)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricPredictionConfig.
+func (in *MetricPredictionConfig) DeepCopy() *MetricPredictionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricPredictionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricQuery) DeepCopyInto(out *MetricQuery) {
 	*out = *in
@@ -330,6 +504,11 @@ func (in *MetricTimeSeries) DeepCopyInto(out *MetricTimeSeries) {
 		*out = make([]Sample, len(*in))
 		copy(*out, *in)
 	}
+	if in.Compact != nil {
+		in, out := &in.Compact, &out.Compact
+		*out = new(CompactSamples)
+		**out = **in
+	}
 	return
 }
 
@@ -369,10 +548,131 @@ func (in MetricTimeSeriesList) DeepCopy() MetricTimeSeriesList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePrediction) DeepCopyInto(out *NodePrediction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePrediction.
+func (in *NodePrediction) DeepCopy() *NodePrediction {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePrediction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePrediction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePredictionList) DeepCopyInto(out *NodePredictionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodePrediction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePredictionList.
+func (in *NodePredictionList) DeepCopy() *NodePredictionList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePredictionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePredictionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePredictionResourceSpec) DeepCopyInto(out *NodePredictionResourceSpec) {
+	*out = *in
+	out.Period = in.Period
+	if in.MetricPredictionConfigs != nil {
+		in, out := &in.MetricPredictionConfigs, &out.MetricPredictionConfigs
+		*out = make([]MetricPredictionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePredictionResourceSpec.
+func (in *NodePredictionResourceSpec) DeepCopy() *NodePredictionResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePredictionResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePredictionResourceStatus) DeepCopyInto(out *NodePredictionResourceStatus) {
+	*out = *in
+	if in.NextPossible != nil {
+		in, out := &in.NextPossible, &out.NextPossible
+		*out = make(Prediction, len(*in))
+		for key, val := range *in {
+			var outVal []Vector
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(TimeSeries, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePredictionResourceStatus.
+func (in *NodePredictionResourceStatus) DeepCopy() *NodePredictionResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePredictionResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Percentile) DeepCopyInto(out *Percentile) {
 	*out = *in
 	out.Histogram = in.Histogram
+	if in.Bounds != nil {
+		in, out := &in.Bounds, &out.Bounds
+		*out = new(Bounds)
+		**out = **in
+	}
 	return
 }
 
@@ -386,6 +686,237 @@ func (in *Percentile) DeepCopy() *Percentile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupPrediction) DeepCopyInto(out *PodGroupPrediction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPrediction.
+func (in *PodGroupPrediction) DeepCopy() *PodGroupPrediction {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupPrediction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodGroupPrediction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupPredictionCondition) DeepCopyInto(out *PodGroupPredictionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPredictionCondition.
+func (in *PodGroupPredictionCondition) DeepCopy() *PodGroupPredictionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupPredictionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupPredictionList) DeepCopyInto(out *PodGroupPredictionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodGroupPrediction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPredictionList.
+func (in *PodGroupPredictionList) DeepCopy() *PodGroupPredictionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupPredictionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodGroupPredictionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupPredictionSpec) DeepCopyInto(out *PodGroupPredictionSpec) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkloadRef != nil {
+		in, out := &in.WorkloadRef, &out.WorkloadRef
+		*out = new(v2beta2.CrossVersionObjectReference)
+		**out = **in
+	}
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	out.PredictionWindow = in.PredictionWindow
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+	if in.MetricPredictionConfigs != nil {
+		in, out := &in.MetricPredictionConfigs, &out.MetricPredictionConfigs
+		*out = make([]MetricPredictionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPredictionSpec.
+func (in *PodGroupPredictionSpec) DeepCopy() *PodGroupPredictionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupPredictionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGroupPredictionStatus) DeepCopyInto(out *PodGroupPredictionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PodGroupPredictionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make(map[string]Prediction, len(*in))
+		for key, val := range *in {
+			var outVal map[corev1.ResourceName]TimeSeries
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(Prediction, len(*in))
+				for key, val := range *in {
+					var outVal []Vector
+					if val == nil {
+						(*out)[key] = nil
+					} else {
+						in, out := &val, &outVal
+						*out = make(TimeSeries, len(*in))
+						copy(*out, *in)
+					}
+					(*out)[key] = outVal
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = make(Prediction, len(*in))
+		for key, val := range *in {
+			var outVal []Vector
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(TimeSeries, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPredictionStatus.
+func (in *PodGroupPredictionStatus) DeepCopy() *PodGroupPredictionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGroupPredictionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Prediction) DeepCopyInto(out *Prediction) {
+	{
+		in := &in
+		*out = make(Prediction, len(*in))
+		for key, val := range *in {
+			var outVal []Vector
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(TimeSeries, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prediction.
+func (in Prediction) DeepCopy() Prediction {
+	if in == nil {
+		return nil
+	}
+	out := new(Prediction)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictionAccuracy) DeepCopyInto(out *PredictionAccuracy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictionAccuracy.
+func (in *PredictionAccuracy) DeepCopy() *PredictionAccuracy {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictionAccuracy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PredictionMetric) DeepCopyInto(out *PredictionMetric) {
 	*out = *in
@@ -432,6 +963,40 @@ func (in *PredictionMetricStatus) DeepCopyInto(out *PredictionMetricStatus) {
 			}
 		}
 	}
+	if in.Instant != nil {
+		in, out := &in.Instant, &out.Instant
+		*out = make([]InstantPrediction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpperBound != nil {
+		in, out := &in.UpperBound, &out.UpperBound
+		*out = make([]*MetricTimeSeries, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MetricTimeSeries)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.LowerBound != nil {
+		in, out := &in.LowerBound, &out.LowerBound
+		*out = make([]*MetricTimeSeries, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MetricTimeSeries)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Accuracy != nil {
+		in, out := &in.Accuracy, &out.Accuracy
+		*out = new(PredictionAccuracy)
+		**out = **in
+	}
 	return
 }
 
@@ -500,6 +1065,26 @@ func (in *Sample) DeepCopy() *Sample {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in TimeSeries) DeepCopyInto(out *TimeSeries) {
+	{
+		in := &in
+		*out = make(TimeSeries, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeSeries.
+func (in TimeSeries) DeepCopy() TimeSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeSeries)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimeSeriesPrediction) DeepCopyInto(out *TimeSeriesPrediction) {
 	*out = *in
@@ -572,6 +1157,11 @@ func (in *TimeSeriesPredictionSpec) DeepCopyInto(out *TimeSeriesPredictionSpec)
 		}
 	}
 	out.TargetRef = in.TargetRef
+	if in.AccuracyPolicy != nil {
+		in, out := &in.AccuracyPolicy, &out.AccuracyPolicy
+		*out = new(AccuracyPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -614,3 +1204,19 @@ func (in *TimeSeriesPredictionStatus) DeepCopy() *TimeSeriesPredictionStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vector) DeepCopyInto(out *Vector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vector.
+func (in *Vector) DeepCopy() *Vector {
+	if in == nil {
+		return nil
+	}
+	out := new(Vector)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,103 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by defaulter-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ClusterNodePrediction{}, func(obj interface{}) { SetObjectDefaults_ClusterNodePrediction(obj.(*ClusterNodePrediction)) })
+	scheme.AddTypeDefaultingFunc(&ClusterNodePredictionList{}, func(obj interface{}) { SetObjectDefaults_ClusterNodePredictionList(obj.(*ClusterNodePredictionList)) })
+	scheme.AddTypeDefaultingFunc(&NodePrediction{}, func(obj interface{}) { SetObjectDefaults_NodePrediction(obj.(*NodePrediction)) })
+	scheme.AddTypeDefaultingFunc(&NodePredictionList{}, func(obj interface{}) { SetObjectDefaults_NodePredictionList(obj.(*NodePredictionList)) })
+	scheme.AddTypeDefaultingFunc(&PodGroupPrediction{}, func(obj interface{}) { SetObjectDefaults_PodGroupPrediction(obj.(*PodGroupPrediction)) })
+	scheme.AddTypeDefaultingFunc(&PodGroupPredictionList{}, func(obj interface{}) { SetObjectDefaults_PodGroupPredictionList(obj.(*PodGroupPredictionList)) })
+	scheme.AddTypeDefaultingFunc(&TimeSeriesPrediction{}, func(obj interface{}) { SetObjectDefaults_TimeSeriesPrediction(obj.(*TimeSeriesPrediction)) })
+	scheme.AddTypeDefaultingFunc(&TimeSeriesPredictionList{}, func(obj interface{}) { SetObjectDefaults_TimeSeriesPredictionList(obj.(*TimeSeriesPredictionList)) })
+	return nil
+}
+
+func SetObjectDefaults_ClusterNodePrediction(in *ClusterNodePrediction) {
+	if in.Spec.PredictionTemplate != nil {
+		for i := range in.Spec.PredictionTemplate.Spec.PredictionMetrics {
+			a := &in.Spec.PredictionTemplate.Spec.PredictionMetrics[i]
+			if a.Algorithm.Linear != nil {
+				SetDefaults_Linear(a.Algorithm.Linear)
+			}
+			if a.Algorithm.HoltWinters != nil {
+				SetDefaults_HoltWinters(a.Algorithm.HoltWinters)
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_ClusterNodePredictionList(in *ClusterNodePredictionList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_ClusterNodePrediction(a)
+	}
+}
+
+func SetObjectDefaults_NodePrediction(in *NodePrediction) {
+	for i := range in.Spec.MetricPredictionConfigs {
+		a := &in.Spec.MetricPredictionConfigs[i]
+		if a.Linear != nil {
+			SetDefaults_Linear(a.Linear)
+		}
+		if a.HoltWinters != nil {
+			SetDefaults_HoltWinters(a.HoltWinters)
+		}
+	}
+}
+
+func SetObjectDefaults_NodePredictionList(in *NodePredictionList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_NodePrediction(a)
+	}
+}
+
+func SetObjectDefaults_PodGroupPrediction(in *PodGroupPrediction) {
+	for i := range in.Spec.MetricPredictionConfigs {
+		a := &in.Spec.MetricPredictionConfigs[i]
+		if a.Linear != nil {
+			SetDefaults_Linear(a.Linear)
+		}
+		if a.HoltWinters != nil {
+			SetDefaults_HoltWinters(a.HoltWinters)
+		}
+	}
+}
+
+func SetObjectDefaults_PodGroupPredictionList(in *PodGroupPredictionList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_PodGroupPrediction(a)
+	}
+}
+
+func SetObjectDefaults_TimeSeriesPrediction(in *TimeSeriesPrediction) {
+	for i := range in.Spec.PredictionMetrics {
+		a := &in.Spec.PredictionMetrics[i]
+		if a.Algorithm.Linear != nil {
+			SetDefaults_Linear(a.Algorithm.Linear)
+		}
+		if a.Algorithm.HoltWinters != nil {
+			SetDefaults_HoltWinters(a.Algorithm.HoltWinters)
+		}
+	}
+}
+
+func SetObjectDefaults_TimeSeriesPredictionList(in *TimeSeriesPredictionList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_TimeSeriesPrediction(a)
+	}
+}
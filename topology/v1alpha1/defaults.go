@@ -0,0 +1,16 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_ManagerPolicy sets the defaults of a ManagerPolicy.
+func SetDefaults_ManagerPolicy(obj *ManagerPolicy) {
+	if obj.TopologyManagerPolicy == "" {
+		obj.TopologyManagerPolicy = TopologyManagerPolicySingleNUMANodePodLevel
+	}
+}
@@ -1,4 +1,5 @@
 // Package v1alpha1 is the v1alpha1 version of the crane API.
 // +k8s:deepcopy-gen=package,register
+// +k8s:defaulter-gen=TypeMeta
 // +groupName=topology.crane.io
 package v1alpha1
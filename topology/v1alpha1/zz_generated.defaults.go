@@ -0,0 +1,30 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by defaulter-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&NodeResourceTopology{}, func(obj interface{}) { SetObjectDefaults_NodeResourceTopology(obj.(*NodeResourceTopology)) })
+	scheme.AddTypeDefaultingFunc(&NodeResourceTopologyList{}, func(obj interface{}) { SetObjectDefaults_NodeResourceTopologyList(obj.(*NodeResourceTopologyList)) })
+	return nil
+}
+
+func SetObjectDefaults_NodeResourceTopology(in *NodeResourceTopology) {
+	SetDefaults_ManagerPolicy(&in.CraneManagerPolicy)
+}
+
+func SetObjectDefaults_NodeResourceTopologyList(in *NodeResourceTopologyList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_NodeResourceTopology(a)
+	}
+}